@@ -0,0 +1,716 @@
+package sqlparse
+
+import "strings"
+
+// Span is the location of a node in the source, from the start of its first token to the end of its last one.
+type Span struct {
+	Start Position
+	End   Position
+}
+
+// Pos returns the span itself, so that every node embedding a Span implements Node.
+func (s Span) Pos() Span {
+	return s
+}
+
+// Text returns the source of the span in data, the input given to the parser.
+func (s Span) Text(data string) string {
+	return data[s.Start.Offset:s.End.Offset]
+}
+
+// Node is a node of the syntax tree returned by Parse.
+type Node interface {
+	Pos() Span
+}
+
+// Stmt is a statement node.
+type Stmt interface {
+	Node
+	stmtNode()
+}
+
+// Expr is an expression node.
+type Expr interface {
+	Node
+	exprNode()
+}
+
+// TableExpr is a node found in a FROM clause: a table, a derived table, a table function or a join.
+type TableExpr interface {
+	Node
+	tableNode()
+}
+
+// QueryBody is the body of a query: a SELECT, a set operation, or a parenthesised query.
+type QueryBody interface {
+	Node
+	queryBodyNode()
+}
+
+// Ident is an identifier, such as a column name or an alias.
+type Ident struct {
+	Span
+	Name   string // the name without quotes nor escapes, see Token.Identifier
+	Quoted bool
+}
+
+// ObjectName is a possibly qualified name, such as schema.table.
+type ObjectName struct {
+	Span
+	Parts []*Ident
+}
+
+// String returns the parts of the name joined by dots, without quotes.
+func (n *ObjectName) String() string {
+	parts := make([]string, len(n.Parts))
+	for i, part := range n.Parts {
+		parts[i] = part.Name
+	}
+	return strings.Join(parts, ".")
+}
+
+// Query is a SELECT statement, with the clauses applying to the whole result of its body.
+type Query struct {
+	Span
+	With      []*CommonTableExpr
+	Recursive bool // WITH RECURSIVE
+	Body      QueryBody
+	OrderBy   []*OrderItem
+	Limit     Expr
+	Offset    Expr
+	Fetch     *Fetch
+	Locks     []*Lock
+}
+
+// CommonTableExpr is a named query of a WITH clause.
+type CommonTableExpr struct {
+	Span
+	Name    *Ident
+	Columns []*Ident
+	Query   *Query
+}
+
+// Select is a SELECT, without the clauses that belong to the Query holding it.
+type Select struct {
+	Span
+	Distinct   bool
+	DistinctOn []Expr // the expressions of DISTINCT ON, as in PostgreSQL
+	Columns    []*SelectItem
+	From       []TableExpr
+	Where      Expr
+	GroupBy    []Expr // the grouping elements, with GroupingExpr for ROLLUP, CUBE and GROUPING SETS
+	Having     Expr
+	Windows    []*NamedWindow
+}
+
+// SelectItem is an expression of the select list, with its optional alias.
+type SelectItem struct {
+	Span
+	Expr  Expr
+	Alias *Ident
+}
+
+// SetOperation combines the results of two queries.
+type SetOperation struct {
+	Span
+	Operator string // UNION, UNION ALL, INTERSECT, EXCEPT DISTINCT...
+	Left     QueryBody
+	Right    QueryBody
+}
+
+// NamedWindow is a window defined by the WINDOW clause, as in WINDOW w AS (PARTITION BY a).
+type NamedWindow struct {
+	Span
+	Name *Ident
+	Spec *WindowSpec
+}
+
+// Values is a VALUES list used as a query, as in (VALUES (1, 2), (3, 4)) AS v (a, b).
+type Values struct {
+	Span
+	Rows [][]Expr
+}
+
+// TableName is a table or view named in a FROM clause.
+type TableName struct {
+	Span
+	Name  *ObjectName
+	Alias *Ident
+}
+
+// DerivedTable is a subquery used as a table.
+type DerivedTable struct {
+	Span
+	Lateral bool
+	Query   *Query
+	Alias   *Ident
+	Columns []*Ident // the column aliases, as in (SELECT 1, 2) AS s (a, b)
+}
+
+// TableFunc is a function returning a table, as in generate_series(1, 10) AS g or UNNEST(a).
+type TableFunc struct {
+	Span
+	Lateral bool
+	Func    *FuncExpr
+	Alias   *Ident
+	Columns []*Ident // the column aliases, as in generate_series(1, 10) AS g (n)
+}
+
+// Join joins two tables.
+type Join struct {
+	Span
+	Type  string // the join keyword: JOIN, LEFT JOIN, CROSS JOIN, NATURAL JOIN...
+	Left  TableExpr
+	Right TableExpr
+	On    Expr
+	Using []*Ident
+}
+
+// OrderItem is an expression of an ORDER BY clause.
+type OrderItem struct {
+	Span
+	Expr      Expr
+	Direction string      // ASC, DESC or empty
+	Nulls     string      // FIRST, LAST or empty
+	OpClass   *ObjectName // the operator class of an index column, as in CREATE INDEX i ON t (a text_pattern_ops)
+}
+
+// Fetch is the FETCH FIRST clause of a query.
+type Fetch struct {
+	Span
+	Count    Expr // nil for a single row, as in FETCH FIRST ROW ONLY
+	WithTies bool
+}
+
+// Lock is a locking clause of a query, as in FOR UPDATE OF t SKIP LOCKED.
+type Lock struct {
+	Span
+	Strength string // UPDATE, NO KEY UPDATE, SHARE or KEY SHARE
+	Of       []*ObjectName
+	Wait     string // NOWAIT, SKIP LOCKED or empty
+}
+
+// Insert is an INSERT statement. Exactly one of Values, Query and DefaultValues is set.
+type Insert struct {
+	Span
+	With                 []*CommonTableExpr
+	Recursive            bool
+	Table                *ObjectName
+	Alias                *Ident
+	Columns              []*Ident
+	Values               [][]Expr // the rows of VALUES
+	Query                *Query
+	DefaultValues        bool
+	OnConflict           *OnConflict
+	OnDuplicateKeyUpdate []*Assignment // ON DUPLICATE KEY UPDATE, as in MySQL
+	Returning            []*SelectItem
+}
+
+// OnConflict is the ON CONFLICT clause of an INSERT, as in PostgreSQL and SQLite.
+type OnConflict struct {
+	Span
+	Columns    []*Ident // the conflict target, as in ON CONFLICT (id)
+	Constraint *Ident   // the conflict target, as in ON CONFLICT ON CONSTRAINT pk
+	DoNothing  bool
+	Set        []*Assignment // DO UPDATE SET
+	Where      Expr          // the condition of DO UPDATE
+}
+
+// Assignment is a column = value item of a SET clause, or a (columns) = value item setting several columns at once, as
+// in SET (a, b) = (1, 2) in PostgreSQL, where the value is a TupleExpr, a ROW constructor or a subquery.
+type Assignment struct {
+	Span
+	Column  *ObjectName   // nil when Columns is set
+	Columns []*ObjectName // the columns of a (columns) = value item
+	Value   Expr
+}
+
+// Update is an UPDATE statement.
+type Update struct {
+	Span
+	With      []*CommonTableExpr
+	Recursive bool
+	Table     TableExpr // the updated table, or joined tables as in MySQL
+	Set       []*Assignment
+	From      []TableExpr
+	Where     Expr
+	Returning []*SelectItem
+}
+
+// Delete is a DELETE statement.
+type Delete struct {
+	Span
+	With      []*CommonTableExpr
+	Recursive bool
+	Table     *TableName
+	Using     []TableExpr
+	Where     Expr
+	Returning []*SelectItem
+}
+
+// Merge is a MERGE statement.
+type Merge struct {
+	Span
+	Target  *TableName
+	Source  TableExpr
+	On      Expr
+	Clauses []*MergeClause
+}
+
+// MergeClause is a WHEN [NOT] MATCHED clause of a MERGE.
+type MergeClause struct {
+	Span
+	Matched   bool
+	BySource  bool          // WHEN NOT MATCHED BY SOURCE, as in SQL Server
+	Condition Expr          // the condition following AND
+	Action    string        // UPDATE, DELETE, INSERT or DO NOTHING
+	Set       []*Assignment // the assignments of UPDATE
+	Columns   []*Ident      // the columns of INSERT
+	Values    []Expr        // the values of INSERT, nil for INSERT DEFAULT VALUES
+}
+
+// CreateTable is a CREATE TABLE statement.
+type CreateTable struct {
+	Span
+	Temporary   bool
+	IfNotExists bool
+	Name        *ObjectName
+	Columns     []*ColumnDef
+	Constraints []*TableConstraint
+	Options     []*TableOption  // the options following the columns, as ENGINE=InnoDB in MySQL
+	PartitionOf *ObjectName     // the parent of a partition, as in PostgreSQL
+	Bound       *PartitionBound // the bound of a partition, with PartitionOf
+	PartitionBy *PartitionBy
+	Query       *Query // CREATE TABLE ... AS SELECT
+}
+
+// ColumnDef is the definition of a column, in CREATE TABLE and ALTER TABLE.
+type ColumnDef struct {
+	Span
+	Name        *Ident
+	Type        *DataType // nil for columns without type, as allowed by SQLite
+	NotNull     bool
+	Default     Expr
+	Constraints []*ColumnConstraint // the constraints besides NOT NULL and DEFAULT, which are kept here too when named
+}
+
+// ColumnConstraint is a constraint or an attribute of a column.
+type ColumnConstraint struct {
+	Span
+	Name       *Ident // CONSTRAINT name
+	Kind       string // PRIMARY KEY, UNIQUE, CHECK, REFERENCES, GENERATED, IDENTITY, AUTO_INCREMENT, COLLATE, CHARACTER SET, COMMENT, ON UPDATE, NOT NULL or DEFAULT
+	Expr       Expr   // the condition of CHECK, the expression of GENERATED, the value of the others
+	References *References
+}
+
+// TableConstraint is a constraint of a table, or an index declared with the table as in MySQL.
+type TableConstraint struct {
+	Span
+	Name       *Ident // CONSTRAINT name, or the name of the index
+	Kind       string // PRIMARY KEY, UNIQUE, FOREIGN KEY, CHECK or INDEX
+	Columns    []*Ident
+	Check      Expr
+	References *References // the referenced table of FOREIGN KEY
+}
+
+// References is the referenced table of a foreign key.
+type References struct {
+	Span
+	Table    *ObjectName
+	Columns  []*Ident
+	OnDelete string // CASCADE, RESTRICT, SET NULL, SET DEFAULT, NO ACTION or empty
+	OnUpdate string
+}
+
+// TableOption is an option following the columns of a table, as ENGINE=InnoDB or DEFAULT CHARSET=utf8mb4.
+type TableOption struct {
+	Span
+	Name  string // in uppercase with a single space between words
+	Value Expr
+}
+
+// PartitionBy is the PARTITION BY clause of a table.
+type PartitionBy struct {
+	Span
+	Method     string // RANGE, LIST, HASH or KEY, followed by COLUMNS in MySQL
+	Exprs      []Expr
+	Count      Expr            // the number of partitions, as in PARTITIONS 4 in MySQL
+	Partitions []*PartitionDef // the partitions declared with the table, as in MySQL
+}
+
+// PartitionDef is a partition declared with its table, as in PARTITION p0 VALUES LESS THAN (10).
+type PartitionDef struct {
+	Span
+	Name     *Ident
+	LessThan []Expr // VALUES LESS THAN, MAXVALUE being a NameExpr
+	In       []Expr // VALUES IN
+}
+
+// PartitionBound is the bound of a partition created with PARTITION OF, as in FOR VALUES FROM (1) TO (10).
+type PartitionBound struct {
+	Span
+	Default bool
+	In      []Expr
+	From    []Expr
+	To      []Expr
+}
+
+// AlterTable is an ALTER TABLE statement.
+type AlterTable struct {
+	Span
+	IfExists bool
+	Name     *ObjectName
+	Actions  []*AlterAction
+}
+
+// AlterAction is an action of ALTER TABLE. Kind tells which of the other fields are set:
+//
+//   - ADD COLUMN: Column, IfNotExists
+//   - ADD CONSTRAINT: Constraint
+//   - DROP COLUMN, DROP CONSTRAINT: Name, IfExists, Cascade
+//   - DROP INDEX, DROP FOREIGN KEY, DROP CHECK: Name, as in MySQL where DROP KEY is DROP INDEX
+//   - DROP PRIMARY KEY: no other field, as in MySQL
+//   - ALTER COLUMN TYPE: Name, Type, Using
+//   - ALTER COLUMN SET DEFAULT: Name, Default
+//   - ALTER COLUMN DROP DEFAULT, ALTER COLUMN SET NOT NULL, ALTER COLUMN DROP NOT NULL: Name
+//   - MODIFY COLUMN, CHANGE COLUMN: Name for CHANGE, Column
+//   - RENAME COLUMN, RENAME CONSTRAINT, RENAME INDEX: Name, NewName
+//   - RENAME TO: Table
+type AlterAction struct {
+	Span
+	Kind        string
+	Name        *Ident
+	NewName     *Ident
+	Table       *ObjectName
+	Column      *ColumnDef
+	Constraint  *TableConstraint
+	Type        *DataType
+	Using       Expr // the conversion of the values to the new type, as in TYPE int USING a::int
+	Default     Expr
+	IfExists    bool
+	IfNotExists bool
+	Cascade     bool
+}
+
+// CreateIndex is a CREATE INDEX statement.
+type CreateIndex struct {
+	Span
+	Unique       bool
+	Concurrently bool
+	IfNotExists  bool
+	Name         *ObjectName // nil for an index named by the database, as in PostgreSQL
+	Table        *ObjectName
+	Using        string // the index method, as in USING gin
+	Columns      []*OrderItem
+	Include      []*Ident
+	Where        Expr
+}
+
+// CreateView is a CREATE VIEW statement.
+type CreateView struct {
+	Span
+	OrReplace    bool
+	Temporary    bool
+	Materialized bool
+	IfNotExists  bool
+	Name         *ObjectName
+	Columns      []*Ident
+	Query        *Query
+}
+
+// Drop is a DROP TABLE, VIEW, MATERIALIZED VIEW or INDEX statement.
+type Drop struct {
+	Span
+	ObjectType   string // TABLE, VIEW, MATERIALIZED VIEW or INDEX
+	Concurrently bool   // DROP INDEX CONCURRENTLY, as in PostgreSQL
+	IfExists     bool
+	Names        []*ObjectName
+	Table        *ObjectName // the table of the index, as in DROP INDEX i ON t in MySQL
+	Cascade      bool
+	Restrict     bool
+}
+
+// NameExpr is a reference to a column, possibly qualified by its table, as in t.a.
+type NameExpr struct {
+	Span
+	Name *ObjectName
+}
+
+// WildcardExpr is *, or t.* with a table.
+type WildcardExpr struct {
+	Span
+	Table *ObjectName // nil for a bare *
+}
+
+// LiteralExpr is a number, a string, TRUE, FALSE or NULL.
+type LiteralExpr struct {
+	Span
+	Token Token
+}
+
+// TypedLiteralExpr is a string preceded by its type, as in DATE '2024-01-31' or INTERVAL '1' DAY.
+type TypedLiteralExpr struct {
+	Span
+	Type  string // DATE, TIME, TIMESTAMP, INTERVAL...
+	Value Token
+	Unit  string // the unit following an interval, as DAY, or empty
+}
+
+// ParamExpr is a bind parameter, see Token.Parameter.
+type ParamExpr struct {
+	Span
+	Token Token
+}
+
+// UnaryExpr is a prefix operator applied to an expression, such as NOT a or -a.
+type UnaryExpr struct {
+	Span
+	Operator string
+	Operand  Expr
+}
+
+// BinaryExpr is an operator between two expressions, such as a + b, a AND b or a IS DISTINCT FROM b.
+type BinaryExpr struct {
+	Span
+	Operator string // the operator symbol, or the keyword in Normalized form
+	Left     Expr
+	Right    Expr
+}
+
+// BetweenExpr is expr [NOT] BETWEEN low AND high.
+type BetweenExpr struct {
+	Span
+	Expr Expr
+	Not  bool
+	Low  Expr
+	High Expr
+}
+
+// InExpr is expr [NOT] IN with a list of values or a subquery.
+type InExpr struct {
+	Span
+	Expr  Expr
+	Not   bool
+	List  []Expr
+	Query *Query // set instead of List for IN (SELECT ...)
+}
+
+// LikeExpr is expr [NOT] LIKE pattern [ESCAPE escape], and the same with ILIKE and SIMILAR TO.
+type LikeExpr struct {
+	Span
+	Operator string // LIKE, ILIKE or SIMILAR TO
+	Not      bool
+	Expr     Expr
+	Pattern  Expr
+	Escape   Expr
+}
+
+// IsExpr is expr IS [NOT] NULL, TRUE, FALSE or UNKNOWN.
+type IsExpr struct {
+	Span
+	Expr  Expr
+	Not   bool
+	Value string // NULL, TRUE, FALSE or UNKNOWN
+}
+
+// CaseExpr is CASE [operand] WHEN ... THEN ... [ELSE ...] END.
+type CaseExpr struct {
+	Span
+	Operand Expr // nil for a searched CASE
+	Whens   []*WhenClause
+	Else    Expr
+}
+
+// WhenClause is a WHEN condition THEN result branch of a CASE.
+type WhenClause struct {
+	Span
+	Condition Expr
+	Result    Expr
+}
+
+// CastExpr converts an expression to a type, with CAST(expr AS type) or expr::type.
+type CastExpr struct {
+	Span
+	Kind string // CAST, TRY_CAST, SAFE_CAST or ::
+	Expr Expr
+	Type *DataType
+}
+
+// ExtractExpr is EXTRACT(field FROM expr).
+type ExtractExpr struct {
+	Span
+	Field string // the field in uppercase, as YEAR or EPOCH
+	Expr  Expr
+}
+
+// SubstringExpr is SUBSTRING(expr FROM start FOR length), either bound being optional. SUBSTRING(s, 1, 3) is a
+// FuncExpr.
+type SubstringExpr struct {
+	Span
+	Expr Expr
+	From Expr
+	For  Expr
+}
+
+// TrimExpr is TRIM([BOTH|LEADING|TRAILING] [chars] FROM expr), or TRIM(BOTH expr). TRIM(s) and TRIM(s, chars) are
+// FuncExprs.
+type TrimExpr struct {
+	Span
+	Side  string // BOTH, LEADING, TRAILING or empty
+	Chars Expr   // the characters to remove, nil for spaces
+	Expr  Expr
+}
+
+// PositionExpr is POSITION(substring IN expr).
+type PositionExpr struct {
+	Span
+	Substring Expr
+	Expr      Expr
+}
+
+// OverlayExpr is OVERLAY(expr PLACING replacement FROM start [FOR length]).
+type OverlayExpr struct {
+	Span
+	Expr    Expr
+	Placing Expr
+	From    Expr
+	For     Expr
+}
+
+// DataType is the type of a cast or a column.
+type DataType struct {
+	Span
+	Name      string // the type name in uppercase with a single space between words, quoted parts kept as they are
+	Args      []Expr // the modifiers, as in VARCHAR(10) or NUMERIC(10, 2)
+	ArrayDims int    // the number of [] following the type
+}
+
+// FuncExpr is a function call.
+type FuncExpr struct {
+	Span
+	Name        *ObjectName
+	Distinct    bool
+	Args        []Expr
+	OrderBy     []*OrderItem // ORDER BY within the arguments, as in string_agg(a, ',' ORDER BY a)
+	WithinGroup []*OrderItem
+	Filter      Expr
+	Over        *WindowSpec
+}
+
+// WindowSpec is the window of a window function call, after OVER.
+type WindowSpec struct {
+	Span
+	Name        *Ident // a named window, as in OVER w, or the window the specification starts from
+	PartitionBy []Expr
+	OrderBy     []*OrderItem
+	Frame       *WindowFrame
+}
+
+// WindowFrame is the frame clause of a window, as in ROWS BETWEEN 1 PRECEDING AND CURRENT ROW.
+type WindowFrame struct {
+	Span
+	Units string // ROWS, RANGE or GROUPS
+	Start *FrameBound
+	End   *FrameBound // nil without BETWEEN
+}
+
+// FrameBound is a bound of a window frame.
+type FrameBound struct {
+	Span
+	Kind   string // UNBOUNDED PRECEDING, UNBOUNDED FOLLOWING, CURRENT ROW, PRECEDING or FOLLOWING
+	Offset Expr   // the offset of PRECEDING and FOLLOWING
+}
+
+// SubscriptExpr is an array or JSON subscript, as in a[1] or j['key'], or an array slice, as in a[1:2] or a[:n].
+type SubscriptExpr struct {
+	Span
+	Expr  Expr
+	Index Expr // the lower bound of a slice, nil when omitted
+	Slice bool
+	Upper Expr // the upper bound of a slice, nil when omitted
+}
+
+// ArrayExpr is an array constructor, as in ARRAY[1, 2].
+type ArrayExpr struct {
+	Span
+	Elems []Expr
+}
+
+// SubqueryExpr is a query between parentheses used as an expression.
+type SubqueryExpr struct {
+	Span
+	Query *Query
+}
+
+// ParenExpr is an expression between parentheses.
+type ParenExpr struct {
+	Span
+	Expr Expr
+}
+
+// DefaultExpr is DEFAULT, used as a value in VALUES and SET.
+type DefaultExpr struct {
+	Span
+}
+
+// TupleExpr is a list of expressions between parentheses, as in (a, b) IN ((1, 2)).
+type TupleExpr struct {
+	Span
+	Exprs []Expr
+}
+
+// GroupingExpr is ROLLUP, CUBE or GROUPING SETS in a GROUP BY clause, as in GROUP BY ROLLUP (a, (b, c)).
+type GroupingExpr struct {
+	Span
+	Kind  string // ROLLUP, CUBE or GROUPING SETS
+	Elems []Expr // a TupleExpr for several columns, with no Exprs for the empty set ()
+}
+
+func (*Query) stmtNode()  {}
+func (*Insert) stmtNode() {}
+func (*Update) stmtNode() {}
+func (*Delete) stmtNode() {}
+func (*Merge) stmtNode()  {}
+
+func (*CreateTable) stmtNode() {}
+func (*AlterTable) stmtNode()  {}
+func (*CreateIndex) stmtNode() {}
+func (*CreateView) stmtNode()  {}
+func (*Drop) stmtNode()        {}
+
+func (*Query) queryBodyNode()        {}
+func (*Select) queryBodyNode()       {}
+func (*SetOperation) queryBodyNode() {}
+func (*Values) queryBodyNode()       {}
+
+func (*TableName) tableNode()    {}
+func (*DerivedTable) tableNode() {}
+func (*TableFunc) tableNode()    {}
+func (*Join) tableNode()         {}
+
+func (*NameExpr) exprNode()         {}
+func (*WildcardExpr) exprNode()     {}
+func (*LiteralExpr) exprNode()      {}
+func (*TypedLiteralExpr) exprNode() {}
+func (*ParamExpr) exprNode()        {}
+func (*UnaryExpr) exprNode()        {}
+func (*BinaryExpr) exprNode()       {}
+func (*BetweenExpr) exprNode()      {}
+func (*InExpr) exprNode()           {}
+func (*LikeExpr) exprNode()         {}
+func (*IsExpr) exprNode()           {}
+func (*CaseExpr) exprNode()         {}
+func (*CastExpr) exprNode()         {}
+func (*ExtractExpr) exprNode()      {}
+func (*SubstringExpr) exprNode()    {}
+func (*TrimExpr) exprNode()         {}
+func (*PositionExpr) exprNode()     {}
+func (*OverlayExpr) exprNode()      {}
+func (*FuncExpr) exprNode()         {}
+func (*SubscriptExpr) exprNode()    {}
+func (*ArrayExpr) exprNode()        {}
+func (*SubqueryExpr) exprNode()     {}
+func (*ParenExpr) exprNode()        {}
+func (*TupleExpr) exprNode()        {}
+func (*GroupingExpr) exprNode()     {}
+func (*DefaultExpr) exprNode()      {}
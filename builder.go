@@ -0,0 +1,165 @@
+package sqlparse
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+)
+
+// LexerBuilder collects the rules of a Lexer. A Lexer never changes once built, so it can be shared by any number of
+// goroutines, while a builder is meant to be used by a single goroutine. Its methods return the builder so that calls
+// can be chained:
+//
+//	lexer := sqlparse.NewLexerBuilder(sqlparse.PostgreSQL).
+//		AddKeyword("REFRESH", sqlparse.TokenKeyword).
+//		Build()
+type LexerBuilder struct {
+	lexer Lexer
+}
+
+// NewLexerBuilder returns a builder starting from the rules of the given dialect.
+func NewLexerBuilder(d Dialect) *LexerBuilder {
+	b := LexerBuilder{
+		lexer: Lexer{
+			builtinRules:     true,
+			comments:         d.Comments(),
+			identifierQuotes: d.IdentifierQuotes(),
+			stringQuotes:     d.StringQuotes(),
+			stringKinds:      newStringKindSet(d.StringKinds()...),
+		},
+	}
+	l := &b.lexer
+
+	l.keywords = make(map[string]keywordRule)
+	for _, keyword := range d.NonReservedKeywords() {
+		b.AddNonReservedKeyword(keyword, TokenKeyword)
+	}
+	for _, keyword := range d.ReservedKeywords() {
+		keywordType := TokenKeyword
+		if keyword == "WITH" {
+			keywordType = TokenKeywordCTE
+		}
+		b.AddKeyword(keyword, keywordType)
+	}
+
+	// a later operator replaces an earlier one with the same symbol, then longest first so that ->> isn't read as ->
+	for _, op := range d.Operators() {
+		if i := slices.IndexFunc(l.operators, func(o Operator) bool { return o.Symbol == op.Symbol }); i >= 0 {
+			l.operators[i] = op
+			continue
+		}
+		l.operators = append(l.operators, op)
+	}
+	slices.SortStableFunc(l.operators, func(a, b Operator) int { return len(b.Symbol) - len(a.Symbol) })
+
+	return &b
+}
+
+// Clone returns a builder starting from the rules of the lexer, to derive a customised lexer from it. The lexer itself
+// is left untouched.
+func (l *Lexer) Clone() *LexerBuilder {
+	return &LexerBuilder{lexer: l.clone()}
+}
+
+// clone returns a copy of the lexer that shares none of its slices nor maps.
+func (l *Lexer) clone() Lexer {
+	c := *l
+	c.operators = slices.Clone(l.operators)
+	c.regexChecks = slices.Clone(l.regexChecks)
+	c.keywords = maps.Clone(l.keywords)
+	return c
+}
+
+// Build returns a lexer with the rules added so far. The builder can still be used afterwards, without changing the
+// lexers it already built.
+func (b *LexerBuilder) Build() *Lexer {
+	l := b.lexer.clone()
+	return &l
+}
+
+// Clear removes all keywords, regex checks and the built-in token rules, useful to start from a clean state before
+// adding your own custom keywords and regex checks.
+func (b *LexerBuilder) Clear() *LexerBuilder {
+	b.lexer.builtinRules = false
+	b.lexer.keywords = make(map[string]keywordRule)
+	b.lexer.regexChecks = nil
+	return b
+}
+
+// AddKeyword adds a reserved keyword, which is always lexed as a keyword, with tokens of type keywordType, usually
+// TokenKeyword. Keywords are matched ignoring case, with Unicode case folding. Adding a non-reserved keyword again makes
+// it reserved.
+func (b *LexerBuilder) AddKeyword(keyword string, keywordType TokenType) *LexerBuilder {
+	b.lexer.keywords[foldKeyword(keyword)] = keywordRule{value: keyword, tokenType: keywordType, reserved: true}
+	return b
+}
+
+// AddNonReservedKeyword adds a keyword that may also be used as a name. It is lexed as a keyword of type keywordType,
+// except next to a dot as in t.name, and the parser accepts it wherever a name is expected, such as a column called
+// "name" in SELECT name FROM foo. Keywords that were already added are left as they are.
+func (b *LexerBuilder) AddNonReservedKeyword(keyword string, keywordType TokenType) *LexerBuilder {
+	key := foldKeyword(keyword)
+	if _, ok := b.lexer.keywords[key]; ok {
+		return b
+	}
+	b.lexer.keywords[key] = keywordRule{value: keyword, tokenType: keywordType}
+	return b
+}
+
+// RemoveKeyword removes a reserved or non-reserved keyword, ignoring case, so that it is lexed as a name.
+func (b *LexerBuilder) RemoveKeyword(keyword string) *LexerBuilder {
+	delete(b.lexer.keywords, foldKeyword(keyword))
+	return b
+}
+
+// NestedComments makes block comments nest, as in PostgreSQL, so each "/*" inside a comment needs its own "*/".
+func (b *LexerBuilder) NestedComments(value bool) *LexerBuilder {
+	if value {
+		b.lexer.comments |= CommentNested
+	} else {
+		b.lexer.comments &^= CommentNested
+	}
+	return b
+}
+
+// AddRegexp adds a custom token rule, tried in the order they were added whenever the built-in rules don't match.
+// The expression is only matched at the current position, as if it started with `^`.
+func (b *LexerBuilder) AddRegexp(re *regexp.Regexp, tokenType TokenType) *LexerBuilder {
+	anchored := regexp.MustCompile(`^(?:` + re.String() + `)`)
+	b.lexer.regexChecks = append(b.lexer.regexChecks, matchInstruction[*regexp.Regexp]{value: anchored, instructionType: tokenType})
+	return b
+}
+
+// AddKeyword adds a reserved keyword to the lexer, see LexerBuilder.AddKeyword.
+//
+// Deprecated: a Lexer may be in use by several goroutines, and changing it isn't safe. Use Clone or NewLexerBuilder
+// and LexerBuilder.AddKeyword instead.
+func (l *Lexer) AddKeyword(keyword string, keywordType TokenType) {
+	l.update(func(b *LexerBuilder) { b.AddKeyword(keyword, keywordType) })
+}
+
+// AddRegexp adds a custom token rule to the lexer, see LexerBuilder.AddRegexp.
+//
+// Deprecated: a Lexer may be in use by several goroutines, and changing it isn't safe. Use Clone or NewLexerBuilder
+// and LexerBuilder.AddRegexp instead.
+func (l *Lexer) AddRegexp(re *regexp.Regexp, tokenType TokenType) {
+	l.update(func(b *LexerBuilder) { b.AddRegexp(re, tokenType) })
+}
+
+// Clear removes all the rules of the lexer, see LexerBuilder.Clear.
+//
+// Deprecated: a Lexer may be in use by several goroutines, and changing it isn't safe. Use Clone or NewLexerBuilder
+// and LexerBuilder.Clear instead.
+func (l *Lexer) Clear() {
+	l.update(func(b *LexerBuilder) { b.Clear() })
+}
+
+// update replaces the rules of the lexer with those of a builder started from them and changed by f.
+func (l *Lexer) update(f func(b *LexerBuilder)) {
+	b := l.Clone()
+	if b.lexer.keywords == nil {
+		b.lexer.keywords = make(map[string]keywordRule)
+	}
+	f(b)
+	*l = b.lexer
+}
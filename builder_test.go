@@ -0,0 +1,116 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestLexerBuilder(t *testing.T) {
+	b := NewLexerBuilder(ANSI).
+		AddKeyword("REFRESH", TokenKeyword).
+		AddNonReservedKeyword("ENGINE", TokenKeyword).
+		StringKinds(StringRaw)
+	l := b.Build()
+
+	assert.True(t, l.IsReservedKeyword("refresh"), "IsReservedKeyword")
+	assert.True(t, l.IsKeyword("engine"), "IsKeyword")
+	assert.False(t, l.IsReservedKeyword("engine"), "IsReservedKeyword")
+
+	tokens, err := l.GetTokens("r'\\d'")
+	require.NoError(t, err, "GetTokens")
+	require.Len(t, tokens, 1, "tokens")
+	assert.Equal(t, StringRaw, tokens[0].StringKind)
+
+	// the lexers already built don't see later changes
+	b.AddKeyword("VACATE", TokenKeyword).NestedComments(true)
+	assert.False(t, l.IsKeyword("vacate"), "IsKeyword after Build")
+	assert.True(t, b.Build().IsKeyword("vacate"), "IsKeyword of a new Build")
+	_, err = l.GetTokens("/* a /* b */")
+	assert.NoError(t, err, "GetTokens without nested comments")
+}
+
+func TestLexerKeywordTypes(t *testing.T) {
+	l := DefaultLexer().Clone().
+		AddKeyword("DIV", TokenOperator).
+		AddNonReservedKeyword("ENGINE", TokenKeywordCTE).
+		Build()
+	tokens, err := l.GetTokens("WITH a AS (SELECT 1 DIV 2) SELECT engine, a.with FROM a")
+	require.NoError(t, err, "GetTokens")
+
+	types := make(map[string]TokenType)
+	for _, token := range tokens {
+		types[token.Value] = token.Type
+	}
+	assert.Equal(t, TokenKeywordCTE, types["WITH"], "WITH")
+	assert.Equal(t, TokenOperator, types["DIV"], "DIV")
+	assert.Equal(t, TokenKeywordCTE, types["engine"], "engine")
+	assert.Equal(t, TokenName, types["with"], "with after a dot")
+	assert.Equal(t, TokenKeyword, types["SELECT"], "SELECT")
+}
+
+func TestLexerClone(t *testing.T) {
+	l := DefaultLexer()
+	assert.Same(t, l, DefaultLexer(), "DefaultLexer")
+
+	custom := l.Clone().
+		AddKeyword("MERGEX", TokenKeyword).
+		AddRegexp(regexp.MustCompile(`\{\w+\}`), TokenName).
+		Build()
+	assert.True(t, custom.IsKeyword("mergex"), "IsKeyword of the clone")
+	assert.False(t, l.IsKeyword("mergex"), "IsKeyword of the original")
+
+	_, err := custom.GetTokens("SELECT {a}")
+	assert.NoError(t, err, "GetTokens of the clone")
+	_, err = l.GetTokens("SELECT {a}")
+	assert.Error(t, err, "GetTokens of the original")
+
+	cleared := l.Clone().Clear().AddKeyword("SELECT", TokenKeyword).Build()
+	assert.False(t, cleared.IsKeyword("from"), "IsKeyword after Clear")
+	assert.True(t, l.IsKeyword("from"), "IsKeyword of the original")
+}
+
+func TestLexerDeprecatedMethods(t *testing.T) {
+	l := NewLexer(ANSI)
+	l.AddKeyword("REFRESH", TokenKeyword)
+	assert.True(t, l.IsReservedKeyword("refresh"), "IsReservedKeyword")
+	assert.False(t, DefaultLexer().IsKeyword("refresh"), "IsKeyword of the default lexer")
+
+	var zero Lexer
+	_, err := zero.GetTokens("a")
+	assert.Error(t, err, "GetTokens of the zero Lexer")
+	zero.AddRegexp(regexp.MustCompile(`\w+`), TokenName)
+	zero.AddKeyword("SELECT", TokenKeyword)
+	tokens, err := zero.GetTokens("select")
+	require.NoError(t, err, "GetTokens after AddRegexp")
+	assert.Equal(t, []Token{{Value: "select", Type: TokenName, End: Position{Offset: 6, Line: 1, Column: 7}, Start: startPosition}}, tokens)
+
+	l.Clear()
+	assert.False(t, l.IsKeyword("select"), "IsKeyword after Clear")
+	_, err = l.GetTokens("SELECT 1")
+	assert.Error(t, err, "GetTokens after Clear")
+}
+
+func TestLexerConcurrent(t *testing.T) {
+	const query = "SELECT a, 'b' FROM c WHERE d->>'e' = $1 -- f"
+	expected, err := GetTokens(query)
+	require.NoError(t, err, "GetTokens")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				DefaultLexer().Clone().AddKeyword("X", TokenKeyword).Build()
+
+				tokens, err := GetTokens(query)
+				assert.NoError(t, err, "GetTokens")
+				assert.Equal(t, expected, tokens)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,94 @@
+package sqlparse
+
+import (
+	"strings"
+	"sync"
+)
+
+//go:generate stringer -type=KeywordCategory -trimprefix=Keyword
+type KeywordCategory int
+
+// Keyword categories, returned by Token.Category.
+const (
+	KeywordNone     KeywordCategory = iota // the token is not a keyword
+	KeywordOther                           // keywords without a more specific category, such as AS or DESC
+	KeywordDML                             // data manipulation statements: SELECT, INSERT, UPDATE, DELETE, MERGE
+	KeywordDDL                             // data definition statements: CREATE, ALTER, DROP, TRUNCATE
+	KeywordDCL                             // data control statements: GRANT, REVOKE
+	KeywordTCL                             // transaction control statements: BEGIN, COMMIT, ROLLBACK, SAVEPOINT
+	KeywordClause                          // clauses: FROM, WHERE, GROUP BY, ORDER BY, LEFT JOIN, LIMIT
+	KeywordDataType                        // data types: INT, VARCHAR, TIMESTAMP WITH TIME ZONE
+	KeywordFunction                        // built-in functions: COUNT, COALESCE, CURRENT_DATE
+	KeywordLiteral                         // literal values: TRUE, FALSE, NULL, UNKNOWN
+	KeywordLogical                         // logical operators: AND, OR, NOT
+)
+
+// IsStatement reports whether the category is one of the statement categories: DML, DDL, DCL or TCL.
+func (c KeywordCategory) IsStatement() bool {
+	return c >= KeywordDML && c <= KeywordTCL
+}
+
+// keywordCategories maps normalized keywords to their category. Keywords missing from it are KeywordOther, except the
+// JOIN family which are clauses.
+var keywordCategories = sync.OnceValue(func() map[string]KeywordCategory {
+	categories := []struct {
+		category KeywordCategory
+		keywords []string
+	}{
+		{KeywordDML, []string{"CALL", "COPY", "DELETE", "INSERT", "INSERT INTO", "MERGE", "SELECT", "UPDATE"}},
+		{KeywordDDL, []string{"ALTER", "COMMENT", "CREATE", "DROP", "RENAME", "TRUNCATE"}},
+		{KeywordDCL, []string{"DENY", "GRANT", "REVOKE"}},
+		{KeywordTCL, []string{"BEGIN", "COMMIT", "RELEASE", "ROLLBACK", "SAVEPOINT", "START"}},
+		{KeywordClause, []string{
+			"EXCEPT", "EXCEPT ALL", "EXCEPT DISTINCT", "FETCH", "FROM", "GROUP BY", "HAVING", "INTERSECT",
+			"INTERSECT ALL", "INTERSECT DISTINCT", "INTO", "LIMIT", "MINUS", "OFFSET", "ON", "ORDER BY", "PARTITION BY",
+			"QUALIFY", "RETURNING", "SET", "TOP", "UNION", "UNION ALL", "UNION DISTINCT", "USING", "VALUES", "WHERE",
+			"WINDOW", "WITH",
+		}},
+		{KeywordDataType, []string{
+			"BIGINT", "BINARY", "BLOB", "BOOLEAN", "CHAR", "CHAR VARYING", "CHARACTER", "CHARACTER VARYING", "CLOB",
+			"DATE", "DEC", "DECFLOAT", "DECIMAL", "DOUBLE", "DOUBLE PRECISION", "FLOAT", "INT", "INTEGER", "INTERVAL",
+			"NCHAR", "NCLOB", "NUMERIC", "REAL", "SMALLINT", "TIME", "TIME WITH TIME ZONE", "TIME WITHOUT TIME ZONE",
+			"TIMESTAMP", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITHOUT TIME ZONE", "UNSIGNED", "VARBINARY", "VARCHAR",
+		}},
+		{KeywordFunction, []string{
+			"ABS", "ACOS", "ARRAY_AGG", "ASIN", "ATAN", "AVG", "CARDINALITY", "CAST", "CEIL", "CEILING", "CHAR_LENGTH",
+			"CHARACTER_LENGTH", "COALESCE", "CONVERT", "CORR", "COS", "COSH", "COUNT", "COVAR_POP", "COVAR_SAMP",
+			"CUME_DIST", "CURRENT_CATALOG", "CURRENT_DATE", "CURRENT_ROLE", "CURRENT_SCHEMA", "CURRENT_TIME",
+			"CURRENT_TIMESTAMP", "CURRENT_USER", "DENSE_RANK", "EXP", "EXTRACT", "FIRST_VALUE", "FLOOR", "GROUPING",
+			"JSON_ARRAY", "JSON_ARRAYAGG", "JSON_EXISTS", "JSON_OBJECT", "JSON_OBJECTAGG", "JSON_QUERY", "JSON_VALUE",
+			"LAG", "LAST_VALUE", "LEAD", "LISTAGG", "LN", "LOCALTIME", "LOCALTIMESTAMP", "LOG", "LOG10", "LOWER", "MAX",
+			"MIN", "MOD", "NORMALIZE", "NTH_VALUE", "NTILE", "NULLIF", "OCTET_LENGTH", "OVERLAY", "PERCENT_RANK",
+			"PERCENTILE_CONT", "PERCENTILE_DISC", "POSITION", "POWER", "RANK", "REGR_AVGX", "REGR_AVGY", "REGR_COUNT",
+			"REGR_INTERCEPT", "REGR_R2", "REGR_SLOPE", "REGR_SXX", "REGR_SXY", "REGR_SYY", "ROW_NUMBER", "SESSION_USER",
+			"SIN", "SINH", "SQRT", "STDDEV_POP", "STDDEV_SAMP", "SUBSTRING", "SUM", "SYSTEM_USER", "TAN", "TANH",
+			"TRANSLATE", "TRIM", "UPPER", "USER", "VAR_POP", "VAR_SAMP", "WIDTH_BUCKET",
+		}},
+		{KeywordLiteral, []string{"FALSE", "NULL", "TRUE", "UNKNOWN"}},
+		{KeywordLogical, []string{"AND", "NOT", "OR", "XOR"}},
+	}
+
+	m := make(map[string]KeywordCategory)
+	for _, c := range categories {
+		for _, keyword := range c.keywords {
+			m[keyword] = c.category
+		}
+	}
+	return m
+})
+
+// Category returns the category of a keyword token, or KeywordNone for other tokens.
+func (t Token) Category() KeywordCategory {
+	if t.Type != TokenKeyword && t.Type != TokenKeywordCTE {
+		return KeywordNone
+	}
+
+	keyword := t.Normalized()
+	if category, ok := keywordCategories()[keyword]; ok {
+		return category
+	}
+	if strings.HasSuffix(keyword, "JOIN") {
+		return KeywordClause
+	}
+	return KeywordOther
+}
@@ -0,0 +1,65 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestKeywordCategories(t *testing.T) {
+	const query = "BEGIN; INSERT INTO t (a) SELECT CAST(x AS timestamp with time zone) FROM u LEFT JOIN v ON true " +
+		"WHERE y IS NOT NULL AND COUNT(z) > 1 ORDER BY a DESC; GRANT ALL ON t TO r; CREATE TABLE w (c DOUBLE PRECISION)"
+	expected := map[string]KeywordCategory{
+		"BEGIN":                    KeywordTCL,
+		"INSERT INTO":              KeywordDML,
+		"SELECT":                   KeywordDML,
+		"CAST":                     KeywordFunction,
+		"AS":                       KeywordOther,
+		"TIMESTAMP WITH TIME ZONE": KeywordDataType,
+		"FROM":                     KeywordClause,
+		"LEFT JOIN":                KeywordClause,
+		"ON":                       KeywordClause,
+		"TRUE":                     KeywordLiteral,
+		"WHERE":                    KeywordClause,
+		"IS NOT NULL":              KeywordOther,
+		"AND":                      KeywordLogical,
+		"COUNT":                    KeywordFunction,
+		"ORDER BY":                 KeywordClause,
+		"DESC":                     KeywordOther,
+		"GRANT":                    KeywordDCL,
+		"ALL":                      KeywordOther,
+		"TO":                       KeywordOther,
+		"CREATE":                   KeywordDDL,
+		"TABLE":                    KeywordOther,
+		"DOUBLE PRECISION":         KeywordDataType,
+	}
+
+	tokens, err := GetTokens(query)
+	require.NoError(t, err, "GetTokens")
+
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		if token.Type != TokenKeyword {
+			assert.Equal(t, KeywordNone, token.Category(), "%s category", token.Value)
+			continue
+		}
+
+		keyword := token.Normalized()
+		seen[keyword] = true
+		category, ok := expected[keyword]
+		if assert.True(t, ok, "unexpected keyword %s", keyword) {
+			assert.Equal(t, category, token.Category(), "%s category", keyword)
+		}
+	}
+	assert.Len(t, seen, len(expected), "keywords found")
+}
+
+func TestKeywordCategoryIsStatement(t *testing.T) {
+	for _, category := range []KeywordCategory{KeywordDML, KeywordDDL, KeywordDCL, KeywordTCL} {
+		assert.True(t, category.IsStatement(), category.String())
+	}
+	for _, category := range []KeywordCategory{KeywordNone, KeywordOther, KeywordClause, KeywordDataType, KeywordLogical} {
+		assert.False(t, category.IsStatement(), category.String())
+	}
+	assert.Equal(t, "DataType", KeywordDataType.String())
+}
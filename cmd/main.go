@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/ipkgs/sqlparse"
 	"io"
@@ -31,6 +32,9 @@ func usage(out io.Writer) {
 	fmt.Fprintf(out, "  -C, --remove-comments: remove comments from the sql query\n")
 	fmt.Fprintf(out, "  -U, --uppercase-keywords: uppercase the keywords\n")
 	fmt.Fprintf(out, "  -j, --json: output the tokens as json (not compatible with format)\n")
+	fmt.Fprintf(out, "  -p, --positions: include the token positions in the json output\n")
+	fmt.Fprintf(out, "      --dialect: sql dialect of the query: ansi (default), postgresql, mysql, sqlite, sqlserver,\n")
+	fmt.Fprintf(out, "                 bigquery or snowflake\n")
 }
 
 type options struct {
@@ -42,6 +46,8 @@ type options struct {
 	removeComments    bool
 	uppercaseKeywords bool
 	json              bool
+	positions         bool
+	dialect           sqlparse.Dialect
 }
 
 func run(out io.Writer, args ...string) error {
@@ -51,7 +57,7 @@ func run(out io.Writer, args ...string) error {
 	}
 
 	startPos := 0
-	var o options
+	o := options{dialect: sqlparse.ANSI}
 	for startPos < len(args) && strings.HasPrefix(args[startPos], "-") {
 		currentOption := args[startPos]
 
@@ -83,6 +89,8 @@ func run(out io.Writer, args ...string) error {
 					o.uppercaseKeywords = true
 				case 'j':
 					o.json = true
+				case 'p':
+					o.positions = true
 				default:
 					return fmt.Errorf("unknown option: -%c", currentOption[i])
 				}
@@ -117,6 +125,18 @@ func run(out io.Writer, args ...string) error {
 				o.uppercaseKeywords = true
 			case "--json":
 				o.json = true
+			case "--positions":
+				o.positions = true
+			case "--dialect":
+				if startPos+1 >= len(args) {
+					return fmt.Errorf("missing parameter for --dialect")
+				}
+				nextParam := args[startPos+1]
+				o.dialect = sqlparse.DialectByName(nextParam)
+				if o.dialect == nil {
+					return fmt.Errorf("unknown dialect: %s", nextParam)
+				}
+				startPos++
 			default:
 				return fmt.Errorf("unknown option: %s", currentOption)
 			}
@@ -140,26 +160,37 @@ func run(out io.Writer, args ...string) error {
 		return fmt.Errorf("missing sql query")
 	}
 
-	var query string
+	if o.format && o.json {
+		return fmt.Errorf("format and json options are not compatible")
+	}
+
+	var reader io.Reader
+	source := "<command-line>"
 	if args[startPos] == "-" {
-		reader := bufio.NewReader(os.Stdin)
-		queryBytes, err := io.ReadAll(reader)
-		if err != nil {
-			return fmt.Errorf("error read stdin: %w", err)
-		}
-		query = string(queryBytes)
+		source = "<stdin>"
+		reader = bufio.NewReader(os.Stdin)
 	} else {
-		query = strings.Join(args[startPos:], " ")
+		reader = strings.NewReader(strings.Join(args[startPos:], " "))
 	}
 
-	tokens, err := sqlparse.GetTokens(query)
+	scanner := sqlparse.NewLexer(o.dialect).NewScanner(reader)
 
-	if err != nil {
-		return fmt.Errorf("error: %w", err)
+	if !o.format && !o.json {
+		// nothing needs the whole list of tokens, print them as they are read
+		for scanner.Scan() {
+			token := scanner.Token()
+			fmt.Fprintf(out, "%s: %s\n", token.Type, token.Value)
+		}
+
+		return scanError(source, scanner.Err())
 	}
 
-	if o.format && o.json {
-		return fmt.Errorf("format and json options are not compatible")
+	var tokens []sqlparse.Token
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Token())
+	}
+	if err := scanError(source, scanner.Err()); err != nil {
+		return err
 	}
 
 	if o.format {
@@ -187,24 +218,34 @@ func run(out io.Writer, args ...string) error {
 	if o.json {
 		var newTokens []sqlparse.Token
 		for _, t := range tokens {
-			if t.Type == sqlparse.TokenComment {
+			if t.Type == sqlparse.TokenComment || t.Type == sqlparse.TokenCommentBlock {
 				continue
 			}
 			if t.Type == sqlparse.TokenKeyword {
-				t.Value = strings.ToUpper(t.Value)
+				t.Value = t.Normalized()
 			}
 
 			newTokens = append(newTokens, t)
 		}
-		if err := sqlparse.Encode(json.NewEncoder(out), newTokens); err != nil {
+		if err := sqlparse.Encode(json.NewEncoder(out), newTokens, sqlparse.EncodeOptionPositions(o.positions)); err != nil {
 			return fmt.Errorf("sqlparse.Encode: %w", err)
 		}
 
 		return nil
 	}
 
-	for _, token := range tokens {
-		fmt.Fprintf(out, "%s: %s\n", token.Type, token.Value)
+	return nil
+}
+
+// scanError formats the errors found while reading the sql query, using the compiler style "source:line:column:"
+// prefix for syntax errors.
+func scanError(source string, err error) error {
+	var syntaxErr *sqlparse.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("%s:%w\n%s", source, err, syntaxErr.Snippet)
+	}
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
 	}
 
 	return nil
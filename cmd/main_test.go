@@ -90,3 +90,35 @@ func TestRemoveCommentsJSON(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, `[{"type":"keyword","value":"SELECT"},{"type":"whitespace","value":" "},{"type":"name","value":"bar"},{"type":"punctuation","value":","},{"type":"whitespace","value":" "},{"type":"name","value":"baz"},{"type":"punctuation","value":","},{"type":"whitespace","value":" "},{"type":"name","value":"baj"},{"type":"punctuation","value":","},{"type":"whitespace","value":" "},{"type":"name","value":"xyz"},{"type":"whitespace","value":" "},{"type":"keyword","value":"FROM"},{"type":"whitespace","value":" "},{"type":"name","value":"foo"},{"type":"whitespace","value":" "}]`+"\n", buf.String())
 }
+
+func TestSingleSelectQueryJSONPositions(t *testing.T) {
+	var buf bytes.Buffer
+	const query = "SELECT *"
+	err := run(&buf, "-jp", query)
+	require.NoError(t, err)
+	require.Equal(t, `[{"type":"keyword","value":"SELECT","start":{"offset":0,"line":1,"column":1},"end":{"offset":6,"line":1,"column":7}},{"type":"whitespace","value":" ","start":{"offset":6,"line":1,"column":7},"end":{"offset":7,"line":1,"column":8}},{"type":"wildcard","value":"*","start":{"offset":7,"line":1,"column":8},"end":{"offset":8,"line":1,"column":9}}]`+"\n", buf.String())
+}
+
+func TestSyntaxError(t *testing.T) {
+	var buf bytes.Buffer
+	err := run(&buf, "SELECT * FROM foo WHERE id = {")
+	require.Error(t, err)
+	require.Equal(t, "<command-line>:1:30: could not parse token starting with '{'\nSELECT * FROM foo WHERE id = {\n                             ^", err.Error())
+}
+
+func TestTokensOutput(t *testing.T) {
+	var buf bytes.Buffer
+	err := run(&buf, "SELECT a FROM foo")
+	require.NoError(t, err)
+	require.Equal(t, "Keyword: SELECT\nWhitespace:  \nName: a\nWhitespace:  \nKeyword: FROM\nWhitespace:  \nName: foo\n", buf.String())
+}
+
+func TestDialect(t *testing.T) {
+	var buf bytes.Buffer
+	err := run(&buf, "--dialect", "mysql", "SELECT \"a\" # comment")
+	require.NoError(t, err)
+	require.Equal(t, "Keyword: SELECT\nWhitespace:  \nString: \"a\"\nWhitespace:  \nComment: # comment\n", buf.String())
+
+	err = run(&buf, "--dialect", "oracle", "SELECT 1")
+	require.EqualError(t, err, "unknown dialect: oracle")
+}
@@ -0,0 +1,954 @@
+package sqlparse
+
+import "strings"
+
+// parseCreate parses CREATE TABLE, CREATE [UNIQUE] INDEX and CREATE [OR REPLACE] [MATERIALIZED] VIEW.
+func (p *parser) parseCreate() (Stmt, error) {
+	start := p.next()
+
+	orReplace := p.isKeyword("OR") && isWordToken(p.peekAt(1), "REPLACE")
+	if orReplace {
+		p.pos += 2
+	}
+	temporary := p.acceptWord("TEMP", "TEMPORARY")
+	unique := p.acceptKeyword("UNIQUE")
+	materialized := p.acceptWord("MATERIALIZED")
+
+	switch {
+	case p.isKeyword("TABLE") && !orReplace && !unique && !materialized:
+		return p.parseCreateTable(start, temporary)
+	case p.isWord("VIEW") && !unique:
+		s := CreateView{OrReplace: orReplace, Temporary: temporary, Materialized: materialized}
+		return p.parseCreateView(start, &s)
+	case p.isWord("INDEX") && !orReplace && !temporary && !materialized:
+		return p.parseCreateIndex(start, unique)
+	}
+	return nil, p.errorf("expected TABLE, VIEW or INDEX, found %s", p.describe())
+}
+
+// parseCreateTable parses CREATE TABLE from the TABLE keyword: the name, followed by the columns and constraints, by
+// PARTITION OF or by AS query, and the table options and PARTITION BY.
+func (p *parser) parseCreateTable(start Token, temporary bool) (*CreateTable, error) {
+	p.pos++ // TABLE
+	s := CreateTable{Temporary: temporary}
+	var err error
+
+	if s.IfNotExists, err = p.parseIfNotExists(); err != nil {
+		return nil, err
+	}
+	if s.Name, err = p.parseObjectName(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.isWord("PARTITION") && isWordToken(p.peekAt(1), "OF"):
+		p.pos += 2
+		if s.PartitionOf, err = p.parseObjectName(); err != nil {
+			return nil, err
+		}
+		if s.Bound, err = p.parsePartitionBound(); err != nil {
+			return nil, err
+		}
+	case p.isPunct("("):
+		if err := p.parseTableElements(&s); err != nil {
+			return nil, err
+		}
+		for isTypeWord(p.peek()) && !p.isKeyword("PARTITION BY", "AS") {
+			option, err := p.parseTableOption()
+			if err != nil {
+				return nil, err
+			}
+			s.Options = append(s.Options, option)
+			p.acceptPunct(",")
+		}
+	case p.acceptKeyword("AS"):
+		if s.Query, err = p.parseQuery(); err != nil {
+			return nil, err
+		}
+		s.Span = p.span(start)
+		return &s, nil
+	default:
+		return nil, p.errorf(`expected "(", found %s`, p.describe())
+	}
+
+	if p.isKeyword("PARTITION BY") {
+		if s.PartitionBy, err = p.parsePartitionBy(); err != nil {
+			return nil, err
+		}
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseTableElements parses the columns and the constraints of a table, between parentheses.
+func (p *parser) parseTableElements(s *CreateTable) error {
+	p.pos++ // (
+	for {
+		if p.isTableConstraint() {
+			constraint, err := p.parseTableConstraint()
+			if err != nil {
+				return err
+			}
+			s.Constraints = append(s.Constraints, constraint)
+		} else {
+			column, err := p.parseColumnDef()
+			if err != nil {
+				return err
+			}
+			s.Columns = append(s.Columns, column)
+		}
+		if !p.acceptPunct(",") {
+			return p.expectPunct(")")
+		}
+	}
+}
+
+// isTableConstraint reports whether a table constraint starts at the current token, rather than a column.
+func (p *parser) isTableConstraint() bool {
+	switch {
+	case p.isWord("CONSTRAINT", "PRIMARY", "FOREIGN", "CHECK", "UNIQUE"):
+		return true
+	case p.isWord("KEY", "INDEX"):
+		// KEY (a) and KEY name (a) in MySQL, but not a column called key, as in key VARCHAR(10)
+		after := p.peekAt(2)
+		return p.peekAt(1).Value == "(" || after.Value == "(" && p.peekAt(3).Type != TokenNumberInteger
+	}
+	return false
+}
+
+// parseTableConstraint parses [CONSTRAINT name] followed by PRIMARY KEY, UNIQUE, FOREIGN KEY, CHECK, or an index as
+// in MySQL.
+func (p *parser) parseTableConstraint() (*TableConstraint, error) {
+	start := p.peek()
+	var c TableConstraint
+	var err error
+
+	if p.acceptWord("CONSTRAINT") {
+		if c.Name, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case p.acceptWord("PRIMARY"):
+		if err := p.expectWord("KEY"); err != nil {
+			return nil, err
+		}
+		c.Kind = "PRIMARY KEY"
+	case p.acceptWord("UNIQUE"):
+		p.acceptWord("KEY", "INDEX")
+		c.Kind = "UNIQUE"
+	case p.acceptWord("FOREIGN"):
+		if err := p.expectWord("KEY"); err != nil {
+			return nil, err
+		}
+		c.Kind = "FOREIGN KEY"
+	case p.acceptWord("CHECK"):
+		c.Kind = "CHECK"
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		if c.Check, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		c.Span = p.span(start)
+		return &c, nil
+	case p.acceptWord("KEY", "INDEX"):
+		c.Kind = "INDEX"
+	default:
+		return nil, p.errorf("expected PRIMARY KEY, UNIQUE, FOREIGN KEY or CHECK, found %s", p.describe())
+	}
+
+	// the name of an index, as in UNIQUE KEY name (a) in MySQL
+	if c.Name == nil && !p.isPunct("(") {
+		if c.Name, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	if c.Columns, err = p.parseIdentList(); err != nil {
+		return nil, err
+	}
+	if c.Kind == "FOREIGN KEY" {
+		if c.References, err = p.parseReferences(); err != nil {
+			return nil, err
+		}
+	}
+
+	c.Span = p.span(start)
+	return &c, nil
+}
+
+// parseColumnDef parses a column name, its optional type, and its constraints.
+func (p *parser) parseColumnDef() (*ColumnDef, error) {
+	start := p.peek()
+	var c ColumnDef
+	var err error
+
+	if c.Name, err = p.parseIdent(); err != nil {
+		return nil, err
+	}
+	if !p.isPunct(",") && !p.isPunct(")") && !p.atEOF() && !p.isWord(columnConstraintWords...) {
+		if c.Type, err = p.parseDataType(); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		constraintStart := p.peek()
+		var constraint ColumnConstraint
+		if p.acceptWord("CONSTRAINT") {
+			if constraint.Name, err = p.parseIdent(); err != nil {
+				return nil, err
+			}
+		}
+
+		switch {
+		case p.acceptKeyword("NOT"):
+			if _, err := p.expectKeyword("NULL"); err != nil {
+				return nil, err
+			}
+			c.NotNull = true
+			if constraint.Name == nil {
+				continue
+			}
+			// keep the name, as in CONSTRAINT nn NOT NULL
+			constraint.Kind = "NOT NULL"
+		case p.acceptKeyword("NULL"):
+			continue
+		case p.acceptKeyword("DEFAULT"):
+			if c.Default, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+			if constraint.Name == nil {
+				continue
+			}
+			// keep the name, as in CONSTRAINT df DEFAULT 0 in SQL Server
+			constraint.Kind, constraint.Expr = "DEFAULT", c.Default
+		case p.acceptWord("PRIMARY"):
+			if err := p.expectWord("KEY"); err != nil {
+				return nil, err
+			}
+			p.acceptWord("ASC", "DESC")
+			constraint.Kind = "PRIMARY KEY"
+		case p.acceptWord("UNIQUE"):
+			p.acceptWord("KEY")
+			constraint.Kind = "UNIQUE"
+		case p.acceptWord("CHECK"):
+			constraint.Kind = "CHECK"
+			if constraint.Expr, err = p.parseParenExpr(); err != nil {
+				return nil, err
+			}
+		case p.isWord("REFERENCES"):
+			constraint.Kind = "REFERENCES"
+			if constraint.References, err = p.parseReferences(); err != nil {
+				return nil, err
+			}
+		case p.isWord("GENERATED") || p.isKeyword("AS") && p.peekAt(1).Value == "(":
+			if err := p.parseGenerated(&constraint); err != nil {
+				return nil, err
+			}
+		case p.acceptWord("IDENTITY"):
+			// IDENTITY(1, 1) as in SQL Server
+			constraint.Kind = "IDENTITY"
+			p.skipParens()
+		case p.acceptWord("AUTO_INCREMENT", "AUTOINCREMENT"):
+			constraint.Kind = "AUTO_INCREMENT"
+		case p.acceptWord("COLLATE", "COMMENT", "CHARSET"):
+			constraint.Kind = strings.ToUpper(p.tokens[p.pos-1].Value)
+			if constraint.Expr, err = p.parsePrefix(); err != nil {
+				return nil, err
+			}
+		case p.isWord("CHARACTER") && isWordToken(p.peekAt(1), "SET"):
+			p.pos += 2
+			constraint.Kind = "CHARACTER SET"
+			if constraint.Expr, err = p.parsePrefix(); err != nil {
+				return nil, err
+			}
+		case p.isKeyword("ON") && isKeywordToken(p.peekAt(1), "UPDATE"):
+			// ON UPDATE CURRENT_TIMESTAMP as in MySQL
+			p.pos += 2
+			constraint.Kind = "ON UPDATE"
+			if constraint.Expr, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		default:
+			if constraint.Name != nil {
+				return nil, p.errorf("expected a constraint, found %s", p.describe())
+			}
+			c.Span = p.span(start)
+			return &c, nil
+		}
+
+		constraint.Span = p.span(constraintStart)
+		c.Constraints = append(c.Constraints, &constraint)
+	}
+}
+
+// columnConstraintWords are the words starting a column constraint, so that a column followed by one of them has no
+// type, as allowed by SQLite.
+var columnConstraintWords = []string{"CHECK", "CONSTRAINT", "DEFAULT", "NOT", "NULL", "PRIMARY", "REFERENCES", "UNIQUE"}
+
+// parseGenerated parses GENERATED ALWAYS|BY DEFAULT AS IDENTITY [(options)], and the generated columns
+// [GENERATED ALWAYS] AS (expr) [STORED|VIRTUAL].
+func (p *parser) parseGenerated(c *ColumnConstraint) error {
+	if p.acceptWord("GENERATED") {
+		if !p.acceptWord("ALWAYS") {
+			if _, err := p.expectKeyword("BY"); err != nil {
+				return err
+			}
+			if _, err := p.expectKeyword("DEFAULT"); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return err
+	}
+
+	if p.acceptWord("IDENTITY") {
+		c.Kind = "IDENTITY"
+		p.skipParens()
+		return nil
+	}
+	c.Kind = "GENERATED"
+	var err error
+	if c.Expr, err = p.parseParenExpr(); err != nil {
+		return err
+	}
+	p.acceptWord("STORED", "VIRTUAL")
+	return nil
+}
+
+// parseReferences parses REFERENCES table [(columns)] [ON DELETE action] [ON UPDATE action].
+func (p *parser) parseReferences() (*References, error) {
+	start := p.peek()
+	if err := p.expectWord("REFERENCES"); err != nil {
+		return nil, err
+	}
+	var r References
+	var err error
+
+	if r.Table, err = p.parseObjectName(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("(") {
+		if r.Columns, err = p.parseIdentList(); err != nil {
+			return nil, err
+		}
+	}
+	for p.isKeyword("ON") && isKeywordToken(p.peekAt(1), "DELETE", "UPDATE") {
+		p.pos++
+		event := p.next().Normalized()
+		action, err := p.parseReferentialAction()
+		if err != nil {
+			return nil, err
+		}
+		if event == "DELETE" {
+			r.OnDelete = action
+		} else {
+			r.OnUpdate = action
+		}
+	}
+
+	r.Span = p.span(start)
+	return &r, nil
+}
+
+// parseReferentialAction parses CASCADE, RESTRICT, SET NULL, SET DEFAULT or NO ACTION.
+func (p *parser) parseReferentialAction() (string, error) {
+	switch {
+	case p.acceptWord("CASCADE"):
+		return "CASCADE", nil
+	case p.acceptWord("RESTRICT"):
+		return "RESTRICT", nil
+	case p.acceptKeyword("SET"):
+		if !p.isWord("NULL", "DEFAULT") {
+			return "", p.errorf("expected NULL or DEFAULT, found %s", p.describe())
+		}
+		return "SET " + strings.ToUpper(p.next().Value), nil
+	case p.acceptWord("NO"):
+		if err := p.expectWord("ACTION"); err != nil {
+			return "", err
+		}
+		return "NO ACTION", nil
+	}
+	return "", p.errorf("expected CASCADE, RESTRICT, SET NULL, SET DEFAULT or NO ACTION, found %s", p.describe())
+}
+
+// parseTableOption parses an option following the columns of a table: words, followed by = and a value.
+func (p *parser) parseTableOption() (*TableOption, error) {
+	start := p.peek()
+	var o TableOption
+	var words []string
+
+	for isTypeWord(p.peek()) {
+		words = append(words, typeWord(p.next()))
+	}
+	o.Name = strings.Join(words, " ")
+	if token := p.peek(); token.Type == TokenOperator && token.Value == "=" {
+		p.pos++
+		var err error
+		if o.Value, err = p.parsePrefix(); err != nil {
+			return nil, err
+		}
+	}
+
+	o.Span = p.span(start)
+	return &o, nil
+}
+
+// parsePartitionBy parses PARTITION BY method (exprs), followed by the partitions declared with the table as in MySQL.
+func (p *parser) parsePartitionBy() (*PartitionBy, error) {
+	start := p.next()
+	var s PartitionBy
+	var err error
+
+	if !p.isWord("RANGE", "LIST", "HASH", "KEY") {
+		return nil, p.errorf("expected RANGE, LIST, HASH or KEY, found %s", p.describe())
+	}
+	s.Method = strings.ToUpper(p.next().Value)
+	if p.acceptWord("COLUMNS") {
+		s.Method += " COLUMNS"
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	if !p.isPunct(")") {
+		if s.Exprs, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	if p.acceptWord("PARTITIONS") {
+		if s.Count, err = p.parsePrefix(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptPunct("(") {
+		for {
+			partition, err := p.parsePartitionDef()
+			if err != nil {
+				return nil, err
+			}
+			s.Partitions = append(s.Partitions, partition)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parsePartitionDef parses PARTITION name [VALUES LESS THAN (exprs)|MAXVALUE|VALUES IN (exprs)].
+func (p *parser) parsePartitionDef() (*PartitionDef, error) {
+	start := p.peek()
+	var d PartitionDef
+	var err error
+
+	if err := p.expectWord("PARTITION"); err != nil {
+		return nil, err
+	}
+	if d.Name, err = p.parseIdent(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("VALUES") {
+		switch {
+		case p.acceptWord("LESS"):
+			if err := p.expectWord("THAN"); err != nil {
+				return nil, err
+			}
+			if p.isPunct("(") {
+				d.LessThan, err = p.parseParenExprList()
+			} else {
+				var maxValue Expr
+				maxValue, err = p.parsePrefix()
+				d.LessThan = []Expr{maxValue}
+			}
+		case p.acceptKeyword("IN"):
+			d.In, err = p.parseParenExprList()
+		default:
+			err = p.errorf("expected LESS THAN or IN, found %s", p.describe())
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d.Span = p.span(start)
+	return &d, nil
+}
+
+// parsePartitionBound parses FOR VALUES IN (exprs), FOR VALUES FROM (exprs) TO (exprs), or DEFAULT.
+func (p *parser) parsePartitionBound() (*PartitionBound, error) {
+	start := p.peek()
+	var b PartitionBound
+	var err error
+
+	if p.acceptKeyword("DEFAULT") {
+		b.Default = true
+		b.Span = p.span(start)
+		return &b, nil
+	}
+	if _, err := p.expectKeyword("FOR"); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("VALUES"); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.acceptKeyword("IN"):
+		b.In, err = p.parseParenExprList()
+	case p.acceptKeyword("FROM"):
+		if b.From, err = p.parseParenExprList(); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectKeyword("TO"); err != nil {
+			return nil, err
+		}
+		b.To, err = p.parseParenExprList()
+	default:
+		err = p.errorf("expected IN or FROM, found %s", p.describe())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b.Span = p.span(start)
+	return &b, nil
+}
+
+// parseAlterTable parses ALTER TABLE [IF EXISTS] name followed by its actions, separated by commas.
+func (p *parser) parseAlterTable() (*AlterTable, error) {
+	start := p.next()
+	var s AlterTable
+	var err error
+
+	if _, err := p.expectKeyword("TABLE"); err != nil {
+		return nil, err
+	}
+	if s.IfExists, err = p.parseIfExists(); err != nil {
+		return nil, err
+	}
+	if s.Name, err = p.parseObjectName(); err != nil {
+		return nil, err
+	}
+	for {
+		action, err := p.parseAlterAction()
+		if err != nil {
+			return nil, err
+		}
+		s.Actions = append(s.Actions, action)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseAlterAction parses an action of ALTER TABLE, see AlterAction for the supported ones.
+func (p *parser) parseAlterAction() (*AlterAction, error) {
+	start := p.peek()
+	var a AlterAction
+	var err error
+
+	switch {
+	case p.acceptWord("ADD"):
+		if p.isTableConstraint() {
+			a.Kind = "ADD CONSTRAINT"
+			a.Constraint, err = p.parseTableConstraint()
+			break
+		}
+		a.Kind = "ADD COLUMN"
+		p.acceptWord("COLUMN")
+		if a.IfNotExists, err = p.parseIfNotExists(); err != nil {
+			return nil, err
+		}
+		a.Column, err = p.parseColumnDef()
+	case p.acceptKeyword("DROP"):
+		err = p.parseDropAction(&a)
+	case p.acceptKeyword("ALTER"):
+		p.acceptWord("COLUMN")
+		if a.Name, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+		err = p.parseAlterColumn(&a)
+	case p.acceptWord("MODIFY"):
+		a.Kind = "MODIFY COLUMN"
+		p.acceptWord("COLUMN")
+		a.Column, err = p.parseColumnDef()
+	case p.acceptWord("CHANGE"):
+		a.Kind = "CHANGE COLUMN"
+		p.acceptWord("COLUMN")
+		if a.Name, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+		a.Column, err = p.parseColumnDef()
+	case p.acceptWord("RENAME"):
+		switch {
+		case p.acceptKeyword("TO", "AS"):
+			a.Kind = "RENAME TO"
+			a.Table, err = p.parseObjectName()
+		case p.acceptWord("CONSTRAINT"):
+			a.Kind = "RENAME CONSTRAINT"
+			err = p.parseRename(&a)
+		case p.isWord("INDEX", "KEY") && p.isIdent(p.peekAt(1)):
+			p.pos++
+			a.Kind = "RENAME INDEX"
+			err = p.parseRename(&a)
+		default:
+			a.Kind = "RENAME COLUMN"
+			p.acceptWord("COLUMN")
+			err = p.parseRename(&a)
+		}
+	default:
+		return nil, p.errorf("expected ADD, DROP, ALTER, MODIFY, CHANGE or RENAME, found %s", p.describe())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.Span = p.span(start)
+	return &a, nil
+}
+
+// parseDropAction parses the object dropped by DROP: [COLUMN] name or CONSTRAINT name, and INDEX name, KEY name,
+// PRIMARY KEY, FOREIGN KEY name or CHECK name as in MySQL.
+func (p *parser) parseDropAction(a *AlterAction) error {
+	var err error
+	switch {
+	case p.isWord("PRIMARY") && isWordToken(p.peekAt(1), "KEY"):
+		p.pos += 2
+		a.Kind = "DROP PRIMARY KEY"
+		return nil
+	case p.isWord("FOREIGN") && isWordToken(p.peekAt(1), "KEY"):
+		p.pos += 2
+		a.Kind = "DROP FOREIGN KEY"
+	case p.isWord("INDEX", "KEY") && p.isIdent(p.peekAt(1)):
+		// but not a column called key, as in DROP key
+		p.pos++
+		a.Kind = "DROP INDEX"
+	case p.isWord("CHECK") && p.isIdent(p.peekAt(1)):
+		p.pos++
+		a.Kind = "DROP CHECK"
+	case p.acceptWord("CONSTRAINT"):
+		a.Kind = "DROP CONSTRAINT"
+	default:
+		a.Kind = "DROP COLUMN"
+		p.acceptWord("COLUMN")
+	}
+
+	if a.IfExists, err = p.parseIfExists(); err != nil {
+		return err
+	}
+	if a.Name, err = p.parseIdent(); err != nil {
+		return err
+	}
+	a.Cascade = p.acceptWord("CASCADE")
+	if !a.Cascade {
+		p.acceptWord("RESTRICT")
+	}
+	return nil
+}
+
+// parseAlterColumn parses the change of ALTER COLUMN name: [SET DATA] TYPE type [USING expr], SET DEFAULT expr, DROP
+// DEFAULT, SET NOT NULL or DROP NOT NULL.
+func (p *parser) parseAlterColumn(a *AlterAction) error {
+	var err error
+	switch {
+	case p.acceptWord("TYPE"):
+		err = p.parseAlterColumnType(a)
+	case p.acceptKeyword("SET"):
+		switch {
+		case p.acceptWord("DATA"):
+			if err := p.expectWord("TYPE"); err != nil {
+				return err
+			}
+			err = p.parseAlterColumnType(a)
+		case p.acceptKeyword("DEFAULT"):
+			a.Kind = "ALTER COLUMN SET DEFAULT"
+			a.Default, err = p.parseExpr()
+		case p.acceptKeyword("NOT"):
+			a.Kind = "ALTER COLUMN SET NOT NULL"
+			_, err = p.expectKeyword("NULL")
+		default:
+			err = p.errorf("expected DATA TYPE, DEFAULT or NOT NULL, found %s", p.describe())
+		}
+	case p.acceptKeyword("DROP"):
+		switch {
+		case p.acceptKeyword("DEFAULT"):
+			a.Kind = "ALTER COLUMN DROP DEFAULT"
+		case p.acceptKeyword("NOT"):
+			a.Kind = "ALTER COLUMN DROP NOT NULL"
+			_, err = p.expectKeyword("NULL")
+		default:
+			err = p.errorf("expected DEFAULT or NOT NULL, found %s", p.describe())
+		}
+	default:
+		err = p.errorf("expected TYPE, SET or DROP, found %s", p.describe())
+	}
+	return err
+}
+
+// parseAlterColumnType parses the type following ALTER COLUMN name TYPE, and the optional USING expr converting the
+// values as in PostgreSQL.
+func (p *parser) parseAlterColumnType(a *AlterAction) error {
+	var err error
+	a.Kind = "ALTER COLUMN TYPE"
+	if a.Type, err = p.parseDataType(); err != nil {
+		return err
+	}
+	if p.acceptKeyword("USING") {
+		a.Using, err = p.parseExpr()
+	}
+	return err
+}
+
+// parseRename parses name TO new_name.
+func (p *parser) parseRename(a *AlterAction) error {
+	var err error
+	if a.Name, err = p.parseIdent(); err != nil {
+		return err
+	}
+	if _, err := p.expectKeyword("TO"); err != nil {
+		return err
+	}
+	a.NewName, err = p.parseIdent()
+	return err
+}
+
+// parseCreateIndex parses CREATE [UNIQUE] INDEX from the INDEX keyword.
+func (p *parser) parseCreateIndex(start Token, unique bool) (*CreateIndex, error) {
+	p.pos++ // INDEX
+	s := CreateIndex{Unique: unique, Concurrently: p.acceptWord("CONCURRENTLY")}
+	var err error
+
+	if s.IfNotExists, err = p.parseIfNotExists(); err != nil {
+		return nil, err
+	}
+	if !p.isKeyword("ON") {
+		if s.Name, err = p.parseObjectName(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expectKeyword("ON"); err != nil {
+		return nil, err
+	}
+	if s.Table, err = p.parseObjectName(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("USING") {
+		if !isTypeWord(p.peek()) {
+			return nil, p.errorf("expected an index method, found %s", p.describe())
+		}
+		s.Using = strings.ToUpper(p.next().Value)
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	if s.Columns, err = p.parseIndexColumns(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	if p.acceptWord("INCLUDE") {
+		if s.Include, err = p.parseIdentList(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("WHERE") {
+		if s.Where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseIndexColumns parses the columns of CREATE INDEX, separated by commas. They are order items, which may also
+// have an operator class as in PostgreSQL: expr [opclass] [ASC|DESC] [NULLS FIRST|LAST].
+func (p *parser) parseIndexColumns() ([]*OrderItem, error) {
+	var items []*OrderItem
+	for {
+		start := p.peek()
+		var item OrderItem
+		var err error
+
+		if item.Expr, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if p.isIdent(p.peek()) && !p.isWord("ASC", "DESC", "NULLS") {
+			if item.OpClass, err = p.parseObjectName(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.parseOrderDirection(&item); err != nil {
+			return nil, err
+		}
+
+		item.Span = p.span(start)
+		items = append(items, &item)
+		if !p.acceptPunct(",") {
+			return items, nil
+		}
+	}
+}
+
+// parseCreateView parses CREATE VIEW from the VIEW keyword.
+func (p *parser) parseCreateView(start Token, s *CreateView) (*CreateView, error) {
+	p.pos++ // VIEW
+	var err error
+
+	if s.IfNotExists, err = p.parseIfNotExists(); err != nil {
+		return nil, err
+	}
+	if s.Name, err = p.parseObjectName(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("(") {
+		if s.Columns, err = p.parseIdentList(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	if s.Query, err = p.parseQuery(); err != nil {
+		return nil, err
+	}
+
+	s.Span = p.span(start)
+	return s, nil
+}
+
+// parseDrop parses DROP TABLE|VIEW|MATERIALIZED VIEW|INDEX [CONCURRENTLY] [IF EXISTS] names [ON table]
+// [CASCADE|RESTRICT].
+func (p *parser) parseDrop() (*Drop, error) {
+	start := p.next()
+	var s Drop
+	var err error
+
+	switch {
+	case p.acceptKeyword("TABLE"):
+		s.ObjectType = "TABLE"
+	case p.acceptWord("VIEW"):
+		s.ObjectType = "VIEW"
+	case p.acceptWord("MATERIALIZED"):
+		if err := p.expectWord("VIEW"); err != nil {
+			return nil, err
+		}
+		s.ObjectType = "MATERIALIZED VIEW"
+	case p.acceptWord("INDEX"):
+		s.ObjectType = "INDEX"
+		s.Concurrently = p.acceptWord("CONCURRENTLY")
+	default:
+		return nil, p.errorf("expected TABLE, VIEW, MATERIALIZED VIEW or INDEX, found %s", p.describe())
+	}
+
+	if s.IfExists, err = p.parseIfExists(); err != nil {
+		return nil, err
+	}
+	for {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		s.Names = append(s.Names, name)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+	if s.ObjectType == "INDEX" && p.acceptKeyword("ON") {
+		if s.Table, err = p.parseObjectName(); err != nil {
+			return nil, err
+		}
+	}
+	s.Cascade = p.acceptWord("CASCADE")
+	s.Restrict = !s.Cascade && p.acceptWord("RESTRICT")
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseIfExists parses an optional IF EXISTS.
+func (p *parser) parseIfExists() (bool, error) {
+	if !p.acceptWord("IF") {
+		return false, nil
+	}
+	_, err := p.expectKeyword("EXISTS")
+	return err == nil, err
+}
+
+// parseIfNotExists parses an optional IF NOT EXISTS.
+func (p *parser) parseIfNotExists() (bool, error) {
+	if !p.acceptWord("IF") {
+		return false, nil
+	}
+	if _, err := p.expectKeyword("NOT"); err != nil {
+		return false, err
+	}
+	_, err := p.expectKeyword("EXISTS")
+	return err == nil, err
+}
+
+// parseParenExpr parses an expression between parentheses, without keeping the parentheses as a ParenExpr.
+func (p *parser) parseParenExpr() (Expr, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return expr, p.expectPunct(")")
+}
+
+// parseParenExprList parses expressions separated by commas, between parentheses.
+func (p *parser) parseParenExprList() ([]Expr, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	exprs, err := p.parseExprList()
+	if err != nil {
+		return nil, err
+	}
+	return exprs, p.expectPunct(")")
+}
+
+// skipParens skips the tokens between parentheses starting at the current token, if any, such as the options of an
+// identity column.
+func (p *parser) skipParens() {
+	if !p.isPunct("(") {
+		return
+	}
+	depth := 0
+	for !p.atEOF() {
+		token := p.next()
+		if token.Type != TokenPunctuation {
+			continue
+		}
+		switch token.Value {
+		case "(":
+			depth++
+		case ")":
+			if depth--; depth == 0 {
+				return
+			}
+		}
+	}
+}
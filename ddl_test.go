@@ -0,0 +1,348 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseCreateTable(t *testing.T) {
+	const data = "CREATE TEMPORARY TABLE IF NOT EXISTS s.t (" +
+		"id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY, " +
+		"name varchar(100) NOT NULL DEFAULT 'x', " +
+		"created timestamp with time zone DEFAULT CURRENT_TIMESTAMP, " +
+		"owner int CONSTRAINT fk REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE, " +
+		"total numeric(10, 2) CHECK (total >= 0), " +
+		"CONSTRAINT t_name UNIQUE (name, owner), " +
+		"FOREIGN KEY (owner) REFERENCES users (id) ON DELETE NO ACTION" +
+		")"
+	s := parseStmt[*CreateTable](t, PostgreSQL, data)
+
+	assert.True(t, s.Temporary, "Temporary")
+	assert.True(t, s.IfNotExists, "IfNotExists")
+	assert.Equal(t, "s.t", s.Name.String(), "Name")
+	require.Len(t, s.Columns, 5, "Columns")
+
+	id := s.Columns[0]
+	assert.Equal(t, "id", id.Name.Name, "Name")
+	assert.Equal(t, "BIGINT", id.Type.Name, "Type")
+	require.Len(t, id.Constraints, 2, "Constraints")
+	assert.Equal(t, "IDENTITY", id.Constraints[0].Kind, "Kind")
+	assert.Equal(t, "PRIMARY KEY", id.Constraints[1].Kind, "Kind")
+
+	name := s.Columns[1]
+	assert.Equal(t, "varchar(100)", name.Type.Text(data), "Type")
+	assert.True(t, name.NotNull, "NotNull")
+	assert.Equal(t, "'x'", name.Default.Pos().Text(data), "Default")
+	assert.Empty(t, name.Constraints, "Constraints")
+
+	created := s.Columns[2]
+	assert.Equal(t, "TIMESTAMP WITH TIME ZONE", created.Type.Name, "Type")
+	require.IsType(t, &FuncExpr{}, created.Default, "Default")
+	assert.Equal(t, "CURRENT_TIMESTAMP", created.Default.(*FuncExpr).Name.String(), "Default")
+
+	owner := s.Columns[3].Constraints[0]
+	assert.Equal(t, "fk", owner.Name.Name, "Name")
+	assert.Equal(t, "REFERENCES", owner.Kind, "Kind")
+	assert.Equal(t, "users", owner.References.Table.String(), "Table")
+	assert.Equal(t, "SET NULL", owner.References.OnDelete, "OnDelete")
+	assert.Equal(t, "CASCADE", owner.References.OnUpdate, "OnUpdate")
+
+	total := s.Columns[4].Constraints[0]
+	assert.Equal(t, "CHECK", total.Kind, "Kind")
+	assert.Equal(t, "total >= 0", total.Expr.Pos().Text(data), "Expr")
+
+	require.Len(t, s.Constraints, 2, "Constraints")
+	assert.Equal(t, "t_name", s.Constraints[0].Name.Name, "Name")
+	assert.Equal(t, "UNIQUE", s.Constraints[0].Kind, "Kind")
+	assert.Len(t, s.Constraints[0].Columns, 2, "Columns")
+	assert.Equal(t, "FOREIGN KEY", s.Constraints[1].Kind, "Kind")
+	assert.Equal(t, "NO ACTION", s.Constraints[1].References.OnDelete, "OnDelete")
+}
+
+func TestParseCreateTableDialects(t *testing.T) {
+	const data = "CREATE TABLE t (" +
+		"id int UNSIGNED NOT NULL AUTO_INCREMENT, " +
+		"name varchar(10) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin COMMENT 'the name', " +
+		"updated datetime ON UPDATE CURRENT_TIMESTAMP, " +
+		"total int AS (id * 2) STORED, " +
+		"PRIMARY KEY (id), " +
+		"KEY idx_name (name)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 " +
+		"PARTITION BY RANGE (id) (PARTITION p0 VALUES LESS THAN (10), PARTITION p1 VALUES LESS THAN MAXVALUE)"
+	s := parseStmt[*CreateTable](t, MySQL, data)
+
+	require.Len(t, s.Columns, 4, "Columns")
+	assert.Equal(t, "INT UNSIGNED", s.Columns[0].Type.Name, "Type")
+	assert.Equal(t, "AUTO_INCREMENT", s.Columns[0].Constraints[0].Kind, "Kind")
+	var kinds []string
+	for _, c := range s.Columns[1].Constraints {
+		kinds = append(kinds, c.Kind)
+	}
+	assert.Equal(t, []string{"CHARACTER SET", "COLLATE", "COMMENT"}, kinds, "Constraints")
+	assert.Equal(t, "ON UPDATE", s.Columns[2].Constraints[0].Kind, "Kind")
+	assert.Equal(t, "GENERATED", s.Columns[3].Constraints[0].Kind, "Kind")
+	assert.Equal(t, "id * 2", s.Columns[3].Constraints[0].Expr.Pos().Text(data), "Expr")
+
+	require.Len(t, s.Constraints, 2, "Constraints")
+	assert.Equal(t, "PRIMARY KEY", s.Constraints[0].Kind, "Kind")
+	assert.Equal(t, "INDEX", s.Constraints[1].Kind, "Kind")
+	assert.Equal(t, "idx_name", s.Constraints[1].Name.Name, "Name")
+
+	require.Len(t, s.Options, 2, "Options")
+	assert.Equal(t, "ENGINE", s.Options[0].Name, "Name")
+	assert.Equal(t, "InnoDB", s.Options[0].Value.Pos().Text(data), "Value")
+	assert.Equal(t, "DEFAULT CHARSET", s.Options[1].Name, "Name")
+
+	require.NotNil(t, s.PartitionBy, "PartitionBy")
+	assert.Equal(t, "RANGE", s.PartitionBy.Method, "Method")
+	require.Len(t, s.PartitionBy.Partitions, 2, "Partitions")
+	assert.Equal(t, "p1", s.PartitionBy.Partitions[1].Name.Name, "Name")
+	assert.Equal(t, "MAXVALUE", s.PartitionBy.Partitions[1].LessThan[0].Pos().Text(data), "LessThan")
+
+	s = parseStmt[*CreateTable](t, SQLite, "CREATE TABLE t (id INTEGER PRIMARY KEY AUTOINCREMENT, data)")
+	require.Len(t, s.Columns, 2, "Columns")
+	assert.Equal(t, "AUTO_INCREMENT", s.Columns[0].Constraints[1].Kind, "Kind")
+	assert.Nil(t, s.Columns[1].Type, "Type")
+
+	s = parseStmt[*CreateTable](t, SQLServer, "CREATE TABLE t (id int IDENTITY(1, 1) NOT NULL)")
+	assert.Equal(t, "IDENTITY", s.Columns[0].Constraints[0].Kind, "Kind")
+	assert.True(t, s.Columns[0].NotNull, "NotNull")
+
+	const named = "CREATE TABLE t (a int CONSTRAINT nn NOT NULL CONSTRAINT df DEFAULT 0 NULL)"
+	s = parseStmt[*CreateTable](t, SQLServer, named)
+	a := s.Columns[0]
+	assert.True(t, a.NotNull, "NotNull")
+	assert.Equal(t, "0", a.Default.Pos().Text(named), "Default")
+	require.Len(t, a.Constraints, 2, "Constraints")
+	assert.Equal(t, "nn", a.Constraints[0].Name.Name, "Name")
+	assert.Equal(t, "NOT NULL", a.Constraints[0].Kind, "Kind")
+	assert.Equal(t, "df", a.Constraints[1].Name.Name, "Name")
+	assert.Equal(t, "DEFAULT", a.Constraints[1].Kind, "Kind")
+	assert.Same(t, a.Default, a.Constraints[1].Expr, "Expr")
+}
+
+func TestParseCreateTablePartitions(t *testing.T) {
+	s := parseStmt[*CreateTable](t, PostgreSQL, "CREATE TABLE m (id int, created date) PARTITION BY RANGE (created)")
+	require.NotNil(t, s.PartitionBy, "PartitionBy")
+	assert.Equal(t, "RANGE", s.PartitionBy.Method, "Method")
+	assert.Len(t, s.PartitionBy.Exprs, 1, "Exprs")
+
+	const data = "CREATE TABLE m_2024 PARTITION OF m FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')"
+	s = parseStmt[*CreateTable](t, PostgreSQL, data)
+	assert.Equal(t, "m", s.PartitionOf.String(), "PartitionOf")
+	require.NotNil(t, s.Bound, "Bound")
+	assert.Equal(t, "'2024-01-01'", s.Bound.From[0].Pos().Text(data), "From")
+	assert.Equal(t, "'2025-01-01'", s.Bound.To[0].Pos().Text(data), "To")
+
+	s = parseStmt[*CreateTable](t, PostgreSQL, "CREATE TABLE m_a PARTITION OF m FOR VALUES IN ('a', 'b')")
+	assert.Len(t, s.Bound.In, 2, "In")
+	s = parseStmt[*CreateTable](t, PostgreSQL, "CREATE TABLE m_other PARTITION OF m DEFAULT")
+	assert.True(t, s.Bound.Default, "Default")
+
+	s = parseStmt[*CreateTable](t, MySQL, "CREATE TABLE t (c char(2)) PARTITION BY LIST COLUMNS (c) (PARTITION p VALUES IN ('a', 'b'))")
+	assert.Equal(t, "LIST COLUMNS", s.PartitionBy.Method, "Method")
+	assert.Len(t, s.PartitionBy.Partitions[0].In, 2, "In")
+
+	const hash = "CREATE TABLE t (id int) PARTITION BY HASH (id) PARTITIONS 4"
+	s = parseStmt[*CreateTable](t, MySQL, hash)
+	assert.Equal(t, "HASH", s.PartitionBy.Method, "Method")
+	assert.Equal(t, "4", s.PartitionBy.Count.Pos().Text(hash), "Count")
+	assert.Empty(t, s.PartitionBy.Partitions, "Partitions")
+
+	s = parseStmt[*CreateTable](t, ANSI, "CREATE TABLE t AS SELECT * FROM u")
+	assert.NotNil(t, s.Query, "Query")
+	assert.Nil(t, s.Columns, "Columns")
+}
+
+func TestParseAlterTable(t *testing.T) {
+	const data = "ALTER TABLE IF EXISTS t " +
+		"ADD COLUMN IF NOT EXISTS c int DEFAULT 0, " +
+		"ADD CONSTRAINT t_c CHECK (c > 0), " +
+		"DROP COLUMN d CASCADE, " +
+		"DROP CONSTRAINT t_e, " +
+		"ALTER COLUMN e TYPE bigint USING e::bigint, " +
+		"ALTER COLUMN f SET DATA TYPE text, " +
+		"ALTER COLUMN g SET DEFAULT now(), " +
+		"ALTER COLUMN h DROP DEFAULT, " +
+		"ALTER i SET NOT NULL, " +
+		"ALTER COLUMN j DROP NOT NULL, " +
+		"RENAME COLUMN k TO l, " +
+		"RENAME CONSTRAINT m TO n, " +
+		"RENAME TO u"
+	s := parseStmt[*AlterTable](t, PostgreSQL, data)
+
+	assert.True(t, s.IfExists, "IfExists")
+	assert.Equal(t, "t", s.Name.String(), "Name")
+	var kinds []string
+	for _, a := range s.Actions {
+		kinds = append(kinds, a.Kind)
+	}
+	assert.Equal(t, []string{
+		"ADD COLUMN", "ADD CONSTRAINT", "DROP COLUMN", "DROP CONSTRAINT", "ALTER COLUMN TYPE", "ALTER COLUMN TYPE",
+		"ALTER COLUMN SET DEFAULT", "ALTER COLUMN DROP DEFAULT", "ALTER COLUMN SET NOT NULL", "ALTER COLUMN DROP NOT NULL",
+		"RENAME COLUMN", "RENAME CONSTRAINT", "RENAME TO",
+	}, kinds, "Actions")
+
+	assert.True(t, s.Actions[0].IfNotExists, "IfNotExists")
+	assert.Equal(t, "c int DEFAULT 0", s.Actions[0].Column.Text(data), "Column")
+	assert.Equal(t, "t_c", s.Actions[1].Constraint.Name.Name, "Constraint")
+	assert.True(t, s.Actions[2].Cascade, "Cascade")
+	assert.Equal(t, "d", s.Actions[2].Name.Name, "Name")
+	assert.Equal(t, "BIGINT", s.Actions[4].Type.Name, "Type")
+	assert.Equal(t, "e::bigint", s.Actions[4].Using.Pos().Text(data), "Using")
+	assert.Nil(t, s.Actions[5].Using, "Using")
+	assert.Equal(t, "now()", s.Actions[6].Default.Pos().Text(data), "Default")
+	assert.Equal(t, "i", s.Actions[8].Name.Name, "Name")
+	assert.Equal(t, "l", s.Actions[10].NewName.Name, "NewName")
+	assert.Equal(t, "u", s.Actions[12].Table.String(), "Table")
+
+	s = parseStmt[*AlterTable](t, MySQL, "ALTER TABLE t MODIFY COLUMN a int NOT NULL, CHANGE b c varchar(10), ADD INDEX idx (a)")
+	require.Len(t, s.Actions, 3, "Actions")
+	assert.Equal(t, "MODIFY COLUMN", s.Actions[0].Kind, "Kind")
+	assert.True(t, s.Actions[0].Column.NotNull, "NotNull")
+	assert.Equal(t, "CHANGE COLUMN", s.Actions[1].Kind, "Kind")
+	assert.Equal(t, "b", s.Actions[1].Name.Name, "Name")
+	assert.Equal(t, "c", s.Actions[1].Column.Name.Name, "Column")
+	assert.Equal(t, "ADD CONSTRAINT", s.Actions[2].Kind, "Kind")
+	assert.Equal(t, "INDEX", s.Actions[2].Constraint.Kind, "Constraint")
+
+	const drops = "ALTER TABLE t DROP INDEX i, DROP KEY k, DROP PRIMARY KEY, DROP FOREIGN KEY fk, DROP CHECK c, " +
+		"RENAME INDEX a TO b, RENAME KEY d TO e"
+	s = parseStmt[*AlterTable](t, MySQL, drops)
+	var names []string
+	kinds = nil
+	for _, a := range s.Actions {
+		kinds = append(kinds, a.Kind)
+		if a.Name != nil {
+			names = append(names, a.Name.Name)
+		}
+	}
+	assert.Equal(t, []string{
+		"DROP INDEX", "DROP INDEX", "DROP PRIMARY KEY", "DROP FOREIGN KEY", "DROP CHECK", "RENAME INDEX", "RENAME INDEX",
+	}, kinds, "Actions")
+	assert.Equal(t, []string{"i", "k", "fk", "c", "a", "d"}, names, "Names")
+	assert.Equal(t, "b", s.Actions[5].NewName.Name, "NewName")
+
+	// key is a column in PostgreSQL
+	s = parseStmt[*AlterTable](t, PostgreSQL, "ALTER TABLE t DROP key, RENAME key TO k")
+	assert.Equal(t, "DROP COLUMN", s.Actions[0].Kind, "Kind")
+	assert.Equal(t, "key", s.Actions[0].Name.Name, "Name")
+	assert.Equal(t, "RENAME COLUMN", s.Actions[1].Kind, "Kind")
+}
+
+func TestParseCreateIndex(t *testing.T) {
+	const data = "CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx ON s.t USING btree (a, lower(b) DESC) " +
+		"INCLUDE (c) WHERE d IS NULL"
+	s := parseStmt[*CreateIndex](t, PostgreSQL, data)
+
+	assert.True(t, s.Unique, "Unique")
+	assert.True(t, s.Concurrently, "Concurrently")
+	assert.True(t, s.IfNotExists, "IfNotExists")
+	assert.Equal(t, "idx", s.Name.String(), "Name")
+	assert.Equal(t, "s.t", s.Table.String(), "Table")
+	assert.Equal(t, "BTREE", s.Using, "Using")
+	require.Len(t, s.Columns, 2, "Columns")
+	assert.Equal(t, "lower(b) DESC", s.Columns[1].Text(data), "Columns")
+	assert.Equal(t, "c", s.Include[0].Name, "Include")
+	assert.Equal(t, "d IS NULL", s.Where.Pos().Text(data), "Where")
+
+	s = parseStmt[*CreateIndex](t, PostgreSQL, "CREATE INDEX ON t (a)")
+	assert.Nil(t, s.Name, "Name")
+	assert.False(t, s.Unique, "Unique")
+	assert.Nil(t, s.Columns[0].OpClass, "OpClass")
+
+	s = parseStmt[*CreateIndex](t, PostgreSQL, "CREATE INDEX i ON t (a text_pattern_ops, b pg_catalog.int4_ops DESC NULLS LAST)")
+	require.Len(t, s.Columns, 2, "Columns")
+	assert.Equal(t, "text_pattern_ops", s.Columns[0].OpClass.String(), "OpClass")
+	assert.Equal(t, "pg_catalog.int4_ops", s.Columns[1].OpClass.String(), "OpClass")
+	assert.Equal(t, "DESC", s.Columns[1].Direction, "Direction")
+	assert.Equal(t, "LAST", s.Columns[1].Nulls, "Nulls")
+}
+
+func TestParseCreateView(t *testing.T) {
+	s := parseStmt[*CreateView](t, PostgreSQL, "CREATE OR REPLACE TEMP VIEW v (a, b) AS SELECT 1, 2")
+	assert.True(t, s.OrReplace, "OrReplace")
+	assert.True(t, s.Temporary, "Temporary")
+	assert.False(t, s.Materialized, "Materialized")
+	assert.Equal(t, "v", s.Name.String(), "Name")
+	assert.Len(t, s.Columns, 2, "Columns")
+	require.NotNil(t, s.Query, "Query")
+
+	s = parseStmt[*CreateView](t, PostgreSQL, "CREATE MATERIALIZED VIEW IF NOT EXISTS m AS WITH x AS (SELECT 1) SELECT * FROM x")
+	assert.True(t, s.Materialized, "Materialized")
+	assert.True(t, s.IfNotExists, "IfNotExists")
+	assert.Len(t, s.Query.With, 1, "With")
+}
+
+func TestParseDrop(t *testing.T) {
+	tests := []struct {
+		dialect      Dialect
+		query        string
+		objectType   string
+		names        []string
+		concurrently bool
+		ifExists     bool
+		cascade      bool
+	}{
+		{dialect: ANSI, query: "DROP TABLE t", objectType: "TABLE", names: []string{"t"}},
+		{dialect: PostgreSQL, query: "DROP TABLE IF EXISTS s.t, u CASCADE", objectType: "TABLE", names: []string{"s.t", "u"}, ifExists: true, cascade: true},
+		{dialect: PostgreSQL, query: "DROP VIEW v RESTRICT", objectType: "VIEW", names: []string{"v"}},
+		{dialect: PostgreSQL, query: "DROP MATERIALIZED VIEW IF EXISTS m", objectType: "MATERIALIZED VIEW", names: []string{"m"}, ifExists: true},
+		{dialect: MySQL, query: "DROP INDEX idx ON t", objectType: "INDEX", names: []string{"idx"}},
+		{dialect: PostgreSQL, query: "DROP INDEX CONCURRENTLY IF EXISTS idx", objectType: "INDEX", names: []string{"idx"}, concurrently: true, ifExists: true},
+		{dialect: PostgreSQL, query: "DROP INDEX CONCURRENTLY idx, s.idx2", objectType: "INDEX", names: []string{"idx", "s.idx2"}, concurrently: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			s := parseStmt[*Drop](t, test.dialect, test.query)
+
+			assert.Equal(t, test.objectType, s.ObjectType, "ObjectType")
+			var names []string
+			for _, name := range s.Names {
+				names = append(names, name.String())
+			}
+			assert.Equal(t, test.names, names, "Names")
+			assert.Equal(t, test.concurrently, s.Concurrently, "Concurrently")
+			assert.Equal(t, test.ifExists, s.IfExists, "IfExists")
+			assert.Equal(t, test.cascade, s.Cascade, "Cascade")
+		})
+	}
+
+	s := parseStmt[*Drop](t, MySQL, "DROP INDEX idx ON t")
+	assert.Equal(t, "t", s.Table.String(), "Table")
+	s = parseStmt[*Drop](t, PostgreSQL, "DROP VIEW v RESTRICT")
+	assert.True(t, s.Restrict, "Restrict")
+}
+
+func TestParseDDLErrors(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		query   string
+		msg     string
+	}{
+		{dialect: ANSI, query: "CREATE SEQUENCE s", msg: `expected TABLE, VIEW or INDEX, found "SEQUENCE"`},
+		{dialect: ANSI, query: "CREATE UNIQUE TABLE t (a int)", msg: `expected TABLE, VIEW or INDEX, found "TABLE"`},
+		{dialect: ANSI, query: "CREATE TABLE t", msg: `expected "(", found end of input`},
+		{dialect: ANSI, query: "CREATE TABLE t (a int,)", msg: `expected a name, found ")"`},
+		{dialect: ANSI, query: "CREATE TABLE t (a int CONSTRAINT c)", msg: `expected a constraint, found ")"`},
+		{dialect: ANSI, query: "CREATE TABLE t (a int REFERENCES u ON DELETE NOTHING)", msg: `expected CASCADE, RESTRICT, SET NULL, SET DEFAULT or NO ACTION, found "NOTHING"`},
+		{dialect: ANSI, query: "CREATE VIEW v SELECT 1", msg: `expected AS, found "SELECT"`},
+		{dialect: ANSI, query: "CREATE INDEX i t (a)", msg: `expected ON, found "t"`},
+		{dialect: ANSI, query: "ALTER TABLE t SET a = 1", msg: `expected ADD, DROP, ALTER, MODIFY, CHANGE or RENAME, found "SET"`},
+		{dialect: ANSI, query: "ALTER TABLE t ALTER COLUMN a SET b", msg: `expected DATA TYPE, DEFAULT or NOT NULL, found "b"`},
+		{dialect: ANSI, query: "DROP SCHEMA s", msg: `expected TABLE, VIEW, MATERIALIZED VIEW or INDEX, found "SCHEMA"`},
+		{dialect: ANSI, query: "DROP TABLE IF t", msg: `expected EXISTS, found "t"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			_, err := NewLexer(test.dialect).Parse(test.query)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, test.msg, syntaxErr.Msg, "Msg")
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package sqlparse
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// CommentStyle is a set of flags describing the comments of a dialect. "--" line comments and "/* */" block comments
+// are always recognised.
+type CommentStyle int
+
+const (
+	CommentNested      CommentStyle = 1 << iota // block comments nest, so each "/*" needs its own "*/"
+	CommentHash                                 // # starts a line comment
+	CommentDoubleSlash                          // // starts a line comment
+)
+
+// Dialect describes the lexical rules of a SQL engine, used by NewLexer to build a lexer for it.
+type Dialect interface {
+	// Name returns the name of the dialect, such as "postgresql".
+	Name() string
+	// ReservedKeywords returns the keywords that can't be used as names without quoting them, in uppercase.
+	ReservedKeywords() []string
+	// NonReservedKeywords returns the keywords that may also be used as names, in uppercase.
+	NonReservedKeywords() []string
+	// IdentifierQuotes returns the characters quoting identifiers, among ", ` and [.
+	IdentifierQuotes() string
+	// StringQuotes returns the characters quoting strings, usually '.
+	StringQuotes() string
+	// StringKinds returns the optional string literal forms, see Lexer.StringKinds.
+	StringKinds() []StringKind
+	// Comments returns the comment styles besides "--" and "/* */".
+	Comments() CommentStyle
+	// Operators returns the operator table of the dialect. Operators are matched longest first, so that ->> is a single
+	// operator rather than -> followed by >.
+	Operators() []Operator
+}
+
+// dialect is a Dialect described by its values, used for the built-in dialects.
+type dialect struct {
+	name             string
+	reserved         []string // in addition to reservedKeywords
+	nonReserved      []string // in addition to nonReservedKeywords
+	unreserved       []string // among reservedKeywords, the ones the engine lets be used as names
+	identifierQuotes string
+	stringQuotes     string
+	stringKinds      []StringKind
+	comments         CommentStyle
+	operators        []Operator // in addition to standardOperators, replacing the ones with the same symbol
+}
+
+func (d *dialect) Name() string {
+	return d.name
+}
+
+func (d *dialect) ReservedKeywords() []string {
+	reserved := slices.DeleteFunc(slices.Clone(reservedKeywords), func(keyword string) bool {
+		return slices.Contains(d.unreserved, keyword)
+	})
+	return slices.Concat(reserved, d.reserved)
+}
+
+func (d *dialect) NonReservedKeywords() []string {
+	return slices.Concat(nonReservedKeywords, d.unreserved, d.nonReserved)
+}
+
+func (d *dialect) IdentifierQuotes() string {
+	return d.identifierQuotes
+}
+
+func (d *dialect) StringQuotes() string {
+	return d.stringQuotes
+}
+
+func (d *dialect) StringKinds() []StringKind {
+	return d.stringKinds
+}
+
+func (d *dialect) Comments() CommentStyle {
+	return d.comments
+}
+
+func (d *dialect) Operators() []Operator {
+	return slices.Concat(standardOperators, d.operators)
+}
+
+// engineUnreserved are the reserved words of the standard that PostgreSQL, MySQL and SQLite all let be used as names,
+// as in SELECT year, value FROM t or count(*) AS count.
+var engineUnreserved = []string{
+	"AVG", "COUNT", "DAY", "HOUR", "MAX", "MIN", "MINUTE", "MONTH", "POSITION", "RESULT", "SECOND", "START", "SUM",
+	"VALUE", "YEAR",
+}
+
+var (
+	// ANSI is the dialect of the default lexer, with the SQL:2016 keywords and accepting the quoting and string forms
+	// of most engines, except for names between brackets, which are array subscripts.
+	ANSI Dialect = &dialect{
+		name:             "ansi",
+		identifierQuotes: defaultIdentifierQuotes,
+		stringQuotes:     "'",
+		stringKinds:      defaultStringKinds,
+		operators:        slices.Concat(jsonOperators, ansiOperators),
+	}
+
+	PostgreSQL Dialect = &dialect{
+		name:             "postgresql",
+		reserved:         []string{"ANALYSE", "ANALYZE", "CONCURRENTLY", "FREEZE", "ISNULL", "NOTNULL", "RETURNING", "VERBOSE"},
+		unreserved:       engineUnreserved,
+		identifierQuotes: `"`,
+		stringQuotes:     "'",
+		stringKinds:      []StringKind{StringEscape, StringNational, StringUnicode, StringHex, StringBit, StringDollar},
+		comments:         CommentNested,
+		operators: slices.Concat(jsonOperators, postgresOperators, []Operator{
+			{"?", PrecedenceOther},
+			{"?|", PrecedenceOther},
+			{"?&", PrecedenceOther},
+		}),
+	}
+
+	MySQL Dialect = &dialect{
+		name: "mysql",
+		reserved: []string{
+			"DATABASE", "DATABASES", "DIV", "INDEX", "KEY", "REGEXP", "RLIKE", "SCHEMAS", "SHOW", "STRAIGHT_JOIN",
+			"UNSIGNED", "XOR", "ZEROFILL",
+		},
+		nonReserved:      []string{"ENGINE", "CHARSET"},
+		unreserved:       engineUnreserved,
+		identifierQuotes: "`",
+		stringQuotes:     `'"`,
+		stringKinds:      []StringKind{StringNational, StringHex, StringBit},
+		comments:         CommentHash,
+		operators: slices.Concat(jsonOperators, []Operator{
+			{"||", PrecedenceOr},
+			{"&&", PrecedenceAnd},
+			{"<=>", PrecedenceComparison},
+			{":=", PrecedenceNone},
+		}),
+	}
+
+	SQLite Dialect = &dialect{
+		name:             "sqlite",
+		reserved:         []string{"AUTOINCREMENT", "GLOB", "PRAGMA", "REGEXP"},
+		unreserved:       engineUnreserved,
+		identifierQuotes: "\"`[",
+		stringQuotes:     "'",
+		stringKinds:      []StringKind{StringHex},
+		operators:        slices.Concat(jsonOperators, []Operator{{"==", PrecedenceComparison}}),
+	}
+
+	SQLServer Dialect = &dialect{
+		name:             "sqlserver",
+		reserved:         []string{"CLUSTERED", "NOCHECK", "NONCLUSTERED", "PIVOT", "TOP", "TRAN", "UNPIVOT"},
+		identifierQuotes: `"[`,
+		stringQuotes:     "'",
+		stringKinds:      []StringKind{StringNational},
+		comments:         CommentNested,
+		operators: []Operator{
+			{"+=", PrecedenceNone},
+			{"-=", PrecedenceNone},
+			{"*=", PrecedenceNone},
+			{"/=", PrecedenceNone},
+			{"%=", PrecedenceNone},
+			{"&=", PrecedenceNone},
+			{"|=", PrecedenceNone},
+			{"^=", PrecedenceNone},
+		},
+	}
+
+	BigQuery Dialect = &dialect{
+		name:             "bigquery",
+		reserved:         []string{"QUALIFY", "STRUCT"},
+		identifierQuotes: "`",
+		stringQuotes:     `'"`,
+		stringKinds:      []StringKind{StringRaw, StringBytes, StringTripleQuoted},
+		comments:         CommentHash,
+	}
+
+	Snowflake Dialect = &dialect{
+		name:             "snowflake",
+		reserved:         []string{"MINUS", "QUALIFY", "REGEXP", "RLIKE", "SAMPLE"},
+		identifierQuotes: `"`,
+		stringQuotes:     "'",
+		stringKinds:      []StringKind{StringHex, StringDollar},
+		comments:         CommentDoubleSlash,
+		operators:        []Operator{{"->", PrecedenceOther}, {"=>", PrecedenceNone}},
+	}
+)
+
+// NewLexer returns a lexer for the SQL of the given dialect, see NewLexerBuilder to customise it.
+func NewLexer(d Dialect) *Lexer {
+	return NewLexerBuilder(d).Build()
+}
+
+// defaultLexer is the lexer of the package functions, built once for the ANSI dialect.
+var defaultLexer = sync.OnceValue(func() *Lexer {
+	return NewLexer(ANSI)
+})
+
+// DefaultLexer returns the lexer for the ANSI dialect shared by the package functions, such as GetTokens. Use Clone to
+// derive a customised lexer from it.
+func DefaultLexer() *Lexer {
+	return defaultLexer()
+}
+
+// builtinDialects are the dialects found by DialectByName.
+var builtinDialects = []Dialect{ANSI, PostgreSQL, MySQL, SQLite, SQLServer, BigQuery, Snowflake}
+
+// DialectByName returns the built-in dialect with the given name, ignoring case, or nil.
+func DialectByName(name string) Dialect {
+	for _, d := range builtinDialects {
+		if strings.EqualFold(d.Name(), name) {
+			return d
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDialects(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		query    string
+		expected []Token
+	}{
+		{
+			dialect: PostgreSQL,
+			query:   `SELECT doc->>'k', "Name" FROM t /* a /* b */ c */ WHERE tags ? 'x' AND id = $1 AND body = $$it's$$`,
+			expected: []Token{
+				{Value: "SELECT", Type: TokenKeyword},
+				{Value: "doc", Type: TokenName},
+				{Value: "->>", Type: TokenOperator},
+				{Value: "'k'", Type: TokenString},
+				{Value: ",", Type: TokenPunctuation},
+				{Value: `"Name"`, Type: TokenQuotedName},
+				{Value: "FROM", Type: TokenKeyword},
+				{Value: "t", Type: TokenName},
+				{Value: "/* a /* b */ c */", Type: TokenCommentBlock},
+				{Value: "WHERE", Type: TokenKeyword},
+				{Value: "tags", Type: TokenName},
+				{Value: "?", Type: TokenOperator},
+				{Value: "'x'", Type: TokenString},
+				{Value: "AND", Type: TokenKeyword},
+				{Value: "id", Type: TokenName},
+				{Value: "=", Type: TokenOperator},
+				{Value: "$1", Type: TokenParameter},
+				{Value: "AND", Type: TokenKeyword},
+				{Value: "body", Type: TokenName},
+				{Value: "=", Type: TokenOperator},
+				{Value: "$$it's$$", Type: TokenString},
+			},
+		},
+		{
+			dialect: MySQL,
+			query:   "SET @n := \"a\"; SELECT `order` FROM t # all\nWHERE x = X'ff' LIMIT 1",
+			expected: []Token{
+				{Value: "SET", Type: TokenKeyword},
+				{Value: "@n", Type: TokenParameter},
+				{Value: ":=", Type: TokenOperator},
+				{Value: `"a"`, Type: TokenString},
+				{Value: ";", Type: TokenPunctuation},
+				{Value: "SELECT", Type: TokenKeyword},
+				{Value: "`order`", Type: TokenQuotedName},
+				{Value: "FROM", Type: TokenKeyword},
+				{Value: "t", Type: TokenName},
+				{Value: "# all\n", Type: TokenComment},
+				{Value: "WHERE", Type: TokenKeyword},
+				{Value: "x", Type: TokenName},
+				{Value: "=", Type: TokenOperator},
+				{Value: "X'ff'", Type: TokenString},
+				{Value: "LIMIT", Type: TokenKeyword},
+				{Value: "1", Type: TokenNumberInteger},
+			},
+		},
+		{
+			dialect: SQLite,
+			query:   "SELECT [a], doc->>'$.b' FROM t WHERE x == 1",
+			expected: []Token{
+				{Value: "SELECT", Type: TokenKeyword},
+				{Value: "[a]", Type: TokenQuotedName},
+				{Value: ",", Type: TokenPunctuation},
+				{Value: "doc", Type: TokenName},
+				{Value: "->>", Type: TokenOperator},
+				{Value: "'$.b'", Type: TokenString},
+				{Value: "FROM", Type: TokenKeyword},
+				{Value: "t", Type: TokenName},
+				{Value: "WHERE", Type: TokenKeyword},
+				{Value: "x", Type: TokenName},
+				{Value: "==", Type: TokenOperator},
+				{Value: "1", Type: TokenNumberInteger},
+			},
+		},
+		{
+			dialect: SQLServer,
+			query:   "SELECT TOP 10 [a] FROM t; SET @x += N'y'",
+			expected: []Token{
+				{Value: "SELECT", Type: TokenKeyword},
+				{Value: "TOP", Type: TokenKeyword},
+				{Value: "10", Type: TokenNumberInteger},
+				{Value: "[a]", Type: TokenQuotedName},
+				{Value: "FROM", Type: TokenKeyword},
+				{Value: "t", Type: TokenName},
+				{Value: ";", Type: TokenPunctuation},
+				{Value: "SET", Type: TokenKeyword},
+				{Value: "@x", Type: TokenParameter},
+				{Value: "+=", Type: TokenOperator},
+				{Value: "N'y'", Type: TokenString},
+			},
+		},
+		{
+			dialect: BigQuery,
+			query:   "SELECT r'\\d', \"s\" FROM `p.d.t` # c",
+			expected: []Token{
+				{Value: "SELECT", Type: TokenKeyword},
+				{Value: "r'\\d'", Type: TokenString},
+				{Value: ",", Type: TokenPunctuation},
+				{Value: `"s"`, Type: TokenString},
+				{Value: "FROM", Type: TokenKeyword},
+				{Value: "`p.d.t`", Type: TokenQuotedName},
+				{Value: "# c", Type: TokenComment},
+			},
+		},
+		{
+			dialect: Snowflake,
+			query:   "SELECT f(a => 1), $$x$$ // c",
+			expected: []Token{
+				{Value: "SELECT", Type: TokenKeyword},
+				{Value: "f", Type: TokenName},
+				{Value: "(", Type: TokenPunctuation},
+				{Value: "a", Type: TokenName},
+				{Value: "=>", Type: TokenOperator},
+				{Value: "1", Type: TokenNumberInteger},
+				{Value: ")", Type: TokenPunctuation},
+				{Value: ",", Type: TokenPunctuation},
+				{Value: "$$x$$", Type: TokenString},
+				{Value: "// c", Type: TokenComment},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect.Name(), func(t *testing.T) {
+			tokens, err := NewLexer(test.dialect).GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+
+			var actual []Token
+			for _, token := range tokens {
+				if token.Type != TokenWhitespace {
+					actual = append(actual, Token{Value: token.Value, Type: token.Type})
+				}
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDialectDifferences(t *testing.T) {
+	_, err := NewLexer(PostgreSQL).GetTokens("SELECT `a`")
+	assert.EqualError(t, err, "1:8: could not parse token starting with '`'")
+
+	tokens, err := NewLexer(ANSI).GetTokens("SELECT # a")
+	require.NoError(t, err, "GetTokens")
+	assert.Equal(t, TokenOperator, tokens[2].Type)
+
+	tokens, err = NewLexer(MySQL).GetTokens(`"a"`)
+	require.NoError(t, err, "GetTokens")
+	assert.Equal(t, TokenString, tokens[0].Type)
+
+	tokens, err = GetTokens(`"a"`)
+	require.NoError(t, err, "GetTokens")
+	assert.Equal(t, TokenQuotedName, tokens[0].Type)
+}
+
+func TestDialectByName(t *testing.T) {
+	assert.Equal(t, PostgreSQL, DialectByName("PostgreSQL"))
+	assert.Equal(t, SQLServer, DialectByName("sqlserver"))
+	assert.Nil(t, DialectByName("oracle"))
+}
+
+func TestDialectUnreservedNames(t *testing.T) {
+	queries := []string{
+		"SELECT name, type, value FROM t WHERE value > 10",
+		"SELECT year, month, day, hour FROM t",
+		"SELECT count(*) AS count, min(a) AS min, max(a) AS max FROM t",
+		"SELECT position, result, start FROM t",
+		"INSERT INTO t (value, year) VALUES (1, 2)",
+		"UPDATE t SET value = 1 WHERE year = 2020",
+		"CREATE TABLE t (value text, year int, count int)",
+	}
+	for _, d := range []Dialect{PostgreSQL, MySQL, SQLite} {
+		lexer := NewLexer(d)
+		for _, query := range queries {
+			t.Run(d.Name()+"/"+query, func(t *testing.T) {
+				_, err := lexer.Parse(query)
+				assert.NoError(t, err)
+			})
+		}
+		assert.False(t, lexer.IsReservedKeyword("VALUE"), d.Name())
+		assert.True(t, lexer.IsReservedKeyword("VALUES"), d.Name())
+	}
+
+	_, err := Parse("SELECT value FROM t")
+	assert.Error(t, err, "VALUE stays reserved in ANSI")
+}
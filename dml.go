@@ -0,0 +1,346 @@
+package sqlparse
+
+// parseInsert parses INSERT [INTO] table [AS alias] [(columns)] VALUES ...|query|DEFAULT VALUES, followed by ON CONFLICT
+// or ON DUPLICATE KEY UPDATE and RETURNING.
+func (p *parser) parseInsert(start Token) (*Insert, error) {
+	var s Insert
+	var err error
+
+	if p.acceptKeyword("INSERT") {
+		p.acceptKeyword("INTO")
+	} else if _, err := p.expectKeyword("INSERT INTO"); err != nil {
+		return nil, err
+	}
+	if s.Table, err = p.parseObjectName(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("AS") {
+		if s.Alias, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	if p.isPunct("(") && !isKeywordToken(p.peekAt(1), "SELECT", "WITH") {
+		if s.Columns, err = p.parseIdentList(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case p.acceptKeyword("VALUES", "VALUE"):
+		if s.Values, err = p.parseValuesRows(); err != nil {
+			return nil, err
+		}
+	case p.isKeyword("DEFAULT") && p.peekAt(1).Normalized() == "VALUES":
+		p.pos += 2
+		s.DefaultValues = true
+	case p.isKeyword("SELECT", "WITH") || p.isPunct("("):
+		if s.Query, err = p.parseQuery(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.errorf("expected VALUES, SELECT or DEFAULT VALUES, found %s", p.describe())
+	}
+
+	if p.isKeyword("ON") {
+		switch next := p.peekAt(1); {
+		case isWordToken(next, "CONFLICT"):
+			if s.OnConflict, err = p.parseOnConflict(); err != nil {
+				return nil, err
+			}
+		case isWordToken(next, "DUPLICATE"):
+			p.pos += 2
+			if err := p.expectWord("KEY"); err != nil {
+				return nil, err
+			}
+			if _, err := p.expectKeyword("UPDATE"); err != nil {
+				return nil, err
+			}
+			if s.OnDuplicateKeyUpdate, err = p.parseAssignments(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.Returning, err = p.parseReturning(); err != nil {
+		return nil, err
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseOnConflict parses ON CONFLICT [(columns)|ON CONSTRAINT name] DO NOTHING|DO UPDATE SET ... [WHERE ...].
+func (p *parser) parseOnConflict() (*OnConflict, error) {
+	start := p.next()
+	p.pos++ // CONFLICT
+	var c OnConflict
+	var err error
+
+	switch {
+	case p.isPunct("("):
+		if c.Columns, err = p.parseIdentList(); err != nil {
+			return nil, err
+		}
+	case p.acceptKeyword("ON"):
+		if err := p.expectWord("CONSTRAINT"); err != nil {
+			return nil, err
+		}
+		if c.Constraint, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expectWord("DO"); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.acceptWord("NOTHING"):
+		c.DoNothing = true
+	case p.acceptKeyword("UPDATE"):
+		if _, err := p.expectKeyword("SET"); err != nil {
+			return nil, err
+		}
+		if c.Set, err = p.parseAssignments(); err != nil {
+			return nil, err
+		}
+		if p.acceptKeyword("WHERE") {
+			if c.Where, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, p.errorf("expected NOTHING or UPDATE, found %s", p.describe())
+	}
+
+	c.Span = p.span(start)
+	return &c, nil
+}
+
+// parseUpdate parses UPDATE table SET ... [FROM tables] [WHERE condition] [RETURNING ...].
+func (p *parser) parseUpdate(start Token) (*Update, error) {
+	var s Update
+	var err error
+
+	if _, err := p.expectKeyword("UPDATE"); err != nil {
+		return nil, err
+	}
+	if s.Table, err = p.parseTableExpr(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("SET"); err != nil {
+		return nil, err
+	}
+	if s.Set, err = p.parseAssignments(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("FROM") {
+		if s.From, err = p.parseTableExprs(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("WHERE") {
+		if s.Where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if s.Returning, err = p.parseReturning(); err != nil {
+		return nil, err
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseDelete parses DELETE [FROM] table [USING tables] [WHERE condition] [RETURNING ...].
+func (p *parser) parseDelete(start Token) (*Delete, error) {
+	var s Delete
+	var err error
+
+	if _, err := p.expectKeyword("DELETE"); err != nil {
+		return nil, err
+	}
+	p.acceptKeyword("FROM")
+	if s.Table, err = p.parseTableName(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("USING") {
+		if s.Using, err = p.parseTableExprs(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("WHERE") {
+		if s.Where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if s.Returning, err = p.parseReturning(); err != nil {
+		return nil, err
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseMerge parses MERGE [INTO] target USING source ON condition, followed by its WHEN clauses.
+func (p *parser) parseMerge() (*Merge, error) {
+	start, err := p.expectKeyword("MERGE")
+	if err != nil {
+		return nil, err
+	}
+	var s Merge
+
+	p.acceptKeyword("INTO")
+	if s.Target, err = p.parseTableName(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("USING"); err != nil {
+		return nil, err
+	}
+	if s.Source, err = p.parseTablePrimary(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("ON"); err != nil {
+		return nil, err
+	}
+	if s.On, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("WHEN") {
+		clause, err := p.parseMergeClause()
+		if err != nil {
+			return nil, err
+		}
+		s.Clauses = append(s.Clauses, clause)
+	}
+	if len(s.Clauses) == 0 {
+		return nil, p.errorf("expected WHEN, found %s", p.describe())
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseMergeClause parses WHEN [NOT] MATCHED [BY SOURCE|TARGET] [AND condition] THEN followed by UPDATE SET, DELETE,
+// INSERT or DO NOTHING.
+func (p *parser) parseMergeClause() (*MergeClause, error) {
+	start := p.next()
+	var c MergeClause
+	var err error
+
+	c.Matched = !p.acceptKeyword("NOT")
+	if err := p.expectWord("MATCHED"); err != nil {
+		return nil, err
+	}
+	if !c.Matched && p.acceptKeyword("BY") {
+		if !p.isWord("SOURCE", "TARGET") {
+			return nil, p.errorf("expected SOURCE or TARGET, found %s", p.describe())
+		}
+		c.BySource = isWordToken(p.next(), "SOURCE")
+	}
+	if p.acceptKeyword("AND") {
+		if c.Condition, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expectKeyword("THEN"); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.acceptKeyword("UPDATE"):
+		c.Action = "UPDATE"
+		if _, err := p.expectKeyword("SET"); err != nil {
+			return nil, err
+		}
+		if c.Set, err = p.parseAssignments(); err != nil {
+			return nil, err
+		}
+	case p.acceptKeyword("DELETE"):
+		c.Action = "DELETE"
+	case p.acceptKeyword("INSERT"):
+		c.Action = "INSERT"
+		if p.isPunct("(") {
+			if c.Columns, err = p.parseIdentList(); err != nil {
+				return nil, err
+			}
+		}
+		if p.acceptKeyword("DEFAULT") {
+			if _, err := p.expectKeyword("VALUES"); err != nil {
+				return nil, err
+			}
+			break
+		}
+		if _, err := p.expectKeyword("VALUES"); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		if c.Values, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+	case p.acceptWord("DO"):
+		if err := p.expectWord("NOTHING"); err != nil {
+			return nil, err
+		}
+		c.Action = "DO NOTHING"
+	default:
+		return nil, p.errorf("expected UPDATE, DELETE, INSERT or DO NOTHING, found %s", p.describe())
+	}
+
+	c.Span = p.span(start)
+	return &c, nil
+}
+
+// parseAssignments parses the column = value and (columns) = value items of a SET clause, separated by commas.
+func (p *parser) parseAssignments() ([]*Assignment, error) {
+	var assignments []*Assignment
+	for {
+		start := p.peek()
+		var a Assignment
+		var err error
+
+		if p.acceptPunct("(") {
+			for {
+				column, err := p.parseObjectName()
+				if err != nil {
+					return nil, err
+				}
+				a.Columns = append(a.Columns, column)
+				if !p.acceptPunct(",") {
+					break
+				}
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+		} else if a.Column, err = p.parseObjectName(); err != nil {
+			return nil, err
+		}
+		if token := p.peek(); token.Type != TokenOperator || token.Value != "=" {
+			return nil, p.errorf(`expected "=", found %s`, p.describe())
+		}
+		p.pos++
+		if a.Value, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+
+		a.Span = p.span(start)
+		assignments = append(assignments, &a)
+		if !p.acceptPunct(",") {
+			return assignments, nil
+		}
+	}
+}
+
+// parseReturning parses an optional RETURNING clause.
+func (p *parser) parseReturning() ([]*SelectItem, error) {
+	if !p.acceptWord("RETURNING") {
+		return nil, nil
+	}
+	return p.parseSelectItems()
+}
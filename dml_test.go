@@ -0,0 +1,191 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// parseStmt parses data with the lexer of the dialect, and checks the type and the span of the statement.
+func parseStmt[T Stmt](t *testing.T, d Dialect, data string) T {
+	t.Helper()
+	stmt, err := NewLexer(d).Parse(data)
+	require.NoError(t, err, "Parse")
+	require.IsType(t, *new(T), stmt)
+	assert.Equal(t, data, stmt.Pos().Text(data), "Span")
+	return stmt.(T)
+}
+
+// assignments returns the assignments as their source text.
+func assignments(data string, set []*Assignment) []string {
+	var texts []string
+	for _, a := range set {
+		texts = append(texts, a.Pos().Text(data))
+	}
+	return texts
+}
+
+func TestParseInsert(t *testing.T) {
+	const data = "INSERT INTO s.t AS x (a, \"b\") VALUES (1, DEFAULT), ($1, lower($2)) " +
+		"ON CONFLICT (a) DO UPDATE SET b = excluded.b WHERE x.b <> excluded.b RETURNING a, b AS c"
+	s := parseStmt[*Insert](t, PostgreSQL, data)
+
+	assert.Equal(t, "s.t", s.Table.String(), "Table")
+	assert.Equal(t, "x", s.Alias.Name, "Alias")
+	require.Len(t, s.Columns, 2, "Columns")
+	assert.Equal(t, "b", s.Columns[1].Name, "Columns")
+	require.Len(t, s.Values, 2, "Values")
+	assert.IsType(t, &DefaultExpr{}, s.Values[0][1], "DEFAULT")
+	assert.IsType(t, &FuncExpr{}, s.Values[1][1], "Values")
+
+	require.NotNil(t, s.OnConflict, "OnConflict")
+	assert.Equal(t, "a", s.OnConflict.Columns[0].Name, "Columns")
+	assert.Equal(t, []string{"b = excluded.b"}, assignments(data, s.OnConflict.Set), "Set")
+	assert.Equal(t, "x.b <> excluded.b", s.OnConflict.Where.Pos().Text(data), "Where")
+
+	require.Len(t, s.Returning, 2, "Returning")
+	assert.Equal(t, "c", s.Returning[1].Alias.Name, "Alias")
+}
+
+func TestParseInsertSources(t *testing.T) {
+	s := parseStmt[*Insert](t, ANSI, "INSERT INTO t (a) SELECT a FROM u WHERE b")
+	require.NotNil(t, s.Query, "Query")
+	assert.Nil(t, s.Values, "Values")
+
+	s = parseStmt[*Insert](t, ANSI, "INSERT INTO t (SELECT a FROM u)")
+	assert.Nil(t, s.Columns, "Columns")
+	require.NotNil(t, s.Query, "Query")
+
+	s = parseStmt[*Insert](t, PostgreSQL, "INSERT INTO t DEFAULT VALUES")
+	assert.True(t, s.DefaultValues, "DefaultValues")
+
+	s = parseStmt[*Insert](t, PostgreSQL, "INSERT INTO t VALUES (1) ON CONFLICT ON CONSTRAINT t_pkey DO NOTHING")
+	require.NotNil(t, s.OnConflict, "OnConflict")
+	assert.Equal(t, "t_pkey", s.OnConflict.Constraint.Name, "Constraint")
+	assert.True(t, s.OnConflict.DoNothing, "DoNothing")
+
+	const data = "INSERT t (a, b) VALUES (1, 2) ON DUPLICATE KEY UPDATE b = VALUES(b), a = a + 1"
+	s = parseStmt[*Insert](t, MySQL, data)
+	assert.Equal(t, "t", s.Table.String(), "Table")
+	assert.Equal(t, []string{"b = VALUES(b)", "a = a + 1"}, assignments(data, s.OnDuplicateKeyUpdate), "OnDuplicateKeyUpdate")
+}
+
+func TestParseUpdate(t *testing.T) {
+	const data = "WITH v AS (SELECT 1) UPDATE t AS x SET a = 1, x.b = DEFAULT FROM u JOIN v ON u.id = v.id " +
+		"WHERE x.id = u.id RETURNING *"
+	s := parseStmt[*Update](t, PostgreSQL, data)
+
+	require.Len(t, s.With, 1, "With")
+	require.IsType(t, &TableName{}, s.Table)
+	assert.Equal(t, "t", s.Table.(*TableName).Name.String(), "Table")
+	assert.Equal(t, "x", s.Table.(*TableName).Alias.Name, "Alias")
+	assert.Equal(t, []string{"a = 1", "x.b = DEFAULT"}, assignments(data, s.Set), "Set")
+	require.Len(t, s.From, 1, "From")
+	assert.IsType(t, &Join{}, s.From[0], "From")
+	assert.Equal(t, "x.id = u.id", s.Where.Pos().Text(data), "Where")
+	require.Len(t, s.Returning, 1, "Returning")
+	assert.IsType(t, &WildcardExpr{}, s.Returning[0].Expr, "Returning")
+
+	s = parseStmt[*Update](t, MySQL, "UPDATE a JOIN b ON a.id = b.id SET a.n = b.n")
+	assert.IsType(t, &Join{}, s.Table, "Table")
+}
+
+func TestParseUpdateColumnList(t *testing.T) {
+	tests := []struct {
+		query     string
+		columns   []string
+		valueType Expr
+	}{
+		{query: "UPDATE t SET (a, b) = (1, 2)", columns: []string{"a", "b"}, valueType: &TupleExpr{}},
+		{query: "UPDATE t SET (a) = ROW(1)", columns: []string{"a"}, valueType: &FuncExpr{}},
+		{query: "UPDATE t SET (a, x.b) = (SELECT c, d FROM u WHERE u.id = t.id)", columns: []string{"a", "x.b"}, valueType: &SubqueryExpr{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			s := parseStmt[*Update](t, PostgreSQL, test.query)
+
+			require.Len(t, s.Set, 1, "Set")
+			a := s.Set[0]
+			assert.Nil(t, a.Column, "Column")
+			var columns []string
+			for _, column := range a.Columns {
+				columns = append(columns, column.String())
+			}
+			assert.Equal(t, test.columns, columns, "Columns")
+			assert.IsType(t, test.valueType, a.Value, "Value")
+		})
+	}
+
+	const data = "UPDATE t SET (a, b) = (1, 2), c = 3"
+	s := parseStmt[*Update](t, PostgreSQL, data)
+	assert.Equal(t, []string{"(a, b) = (1, 2)", "c = 3"}, assignments(data, s.Set), "Set")
+}
+
+func TestParseDelete(t *testing.T) {
+	const data = "DELETE FROM t USING u, v WHERE t.id = u.id RETURNING t.id"
+	s := parseStmt[*Delete](t, PostgreSQL, data)
+	assert.Equal(t, "t", s.Table.Name.String(), "Table")
+	assert.Nil(t, s.Table.Alias, "Alias")
+	assert.Len(t, s.Using, 2, "Using")
+	assert.Equal(t, "t.id = u.id", s.Where.Pos().Text(data), "Where")
+	assert.Len(t, s.Returning, 1, "Returning")
+
+	s = parseStmt[*Delete](t, ANSI, "DELETE FROM t RETURNING a")
+	assert.Nil(t, s.Table.Alias, "RETURNING isn't an alias")
+	assert.Len(t, s.Returning, 1, "Returning")
+}
+
+func TestParseMerge(t *testing.T) {
+	const data = "MERGE INTO t AS x USING (SELECT * FROM u) AS s ON x.id = s.id " +
+		"WHEN MATCHED AND s.deleted THEN DELETE " +
+		"WHEN MATCHED THEN UPDATE SET n = s.n " +
+		"WHEN NOT MATCHED THEN INSERT (id, n) VALUES (s.id, s.n) " +
+		"WHEN NOT MATCHED BY SOURCE THEN DELETE"
+	s := parseStmt[*Merge](t, SQLServer, data)
+
+	assert.Equal(t, "t", s.Target.Name.String(), "Target")
+	assert.Equal(t, "x", s.Target.Alias.Name, "Alias")
+	require.IsType(t, &DerivedTable{}, s.Source)
+	assert.Equal(t, "s", s.Source.(*DerivedTable).Alias.Name, "Alias")
+	assert.Equal(t, "x.id = s.id", s.On.Pos().Text(data), "On")
+
+	require.Len(t, s.Clauses, 4, "Clauses")
+	assert.True(t, s.Clauses[0].Matched, "Matched")
+	assert.Equal(t, "DELETE", s.Clauses[0].Action, "Action")
+	assert.Equal(t, "s.deleted", s.Clauses[0].Condition.Pos().Text(data), "Condition")
+	assert.Equal(t, []string{"n = s.n"}, assignments(data, s.Clauses[1].Set), "Set")
+	assert.False(t, s.Clauses[2].Matched, "Matched")
+	assert.Equal(t, "INSERT", s.Clauses[2].Action, "Action")
+	assert.Len(t, s.Clauses[2].Columns, 2, "Columns")
+	assert.Len(t, s.Clauses[2].Values, 2, "Values")
+	assert.Equal(t, "WHEN NOT MATCHED THEN INSERT (id, n) VALUES (s.id, s.n)", s.Clauses[2].Text(data), "Span")
+	assert.True(t, s.Clauses[3].BySource, "BySource")
+}
+
+func TestParseDMLErrors(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		query   string
+		msg     string
+	}{
+		{dialect: ANSI, query: "INSERT INTO t", msg: "expected VALUES, SELECT or DEFAULT VALUES, found end of input"},
+		{dialect: ANSI, query: "UPDATE t SET a", msg: `expected "=", found end of input`},
+		{dialect: ANSI, query: "UPDATE t WHERE a = 1", msg: `expected SET, found "WHERE"`},
+		{dialect: PostgreSQL, query: "UPDATE t SET (a, b = (1, 2)", msg: `expected ")", found "="`},
+		{dialect: PostgreSQL, query: "UPDATE t SET (a, b) (1, 2)", msg: `expected "=", found "("`},
+		{dialect: PostgreSQL, query: "INSERT INTO t VALUES (1) ON CONFLICT DO", msg: "expected NOTHING or UPDATE, found end of input"},
+		{dialect: ANSI, query: "MERGE INTO t USING u ON a = b", msg: "expected WHEN, found end of input"},
+		{dialect: ANSI, query: "MERGE INTO t USING u ON a = b WHEN MATCHED THEN SELECT", msg: `expected UPDATE, DELETE, INSERT or DO NOTHING, found "SELECT"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			_, err := NewLexer(test.dialect).Parse(test.query)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, test.msg, syntaxErr.Msg, "Msg")
+		})
+	}
+}
@@ -4,28 +4,61 @@ import (
 	"strings"
 )
 
+type jsonPosition struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
 type jsonToken struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
+	Type  string        `json:"type"`
+	Value string        `json:"value"`
+	Start *jsonPosition `json:"start,omitempty"`
+	End   *jsonPosition `json:"end,omitempty"`
 }
 
 type Encoder interface {
 	Encode(v any) error
 }
 
+type EncodeOption func(*encodeOptionList)
+
+// EncodeOptionPositions includes the start and end positions of every token in the output.
+func EncodeOptionPositions(value bool) EncodeOption {
+	return func(e *encodeOptionList) {
+		e.positions = value
+	}
+}
+
+type encodeOptionList struct {
+	positions bool
+}
+
+func newJSONPosition(p Position) *jsonPosition {
+	return &jsonPosition{Offset: p.Offset, Line: p.Line, Column: p.Column}
+}
+
 // Encode writes the tokens to the encoder in JSON format.
 //
 // One of the many ways to use can be as simple as `sqlparse.Encode(json.NewEncoder(os.Stdout), tokens)`.
-func Encode(w Encoder, tokens []Token) error {
+func Encode(w Encoder, tokens []Token, optionList ...EncodeOption) error {
+	var options encodeOptionList
+	for _, option := range optionList {
+		option(&options)
+	}
+
 	var tokenList []jsonToken
 	for _, t := range tokens {
-		tokenList = append(
-			tokenList,
-			jsonToken{
-				strings.ToLower(t.Type.String()),
-				t.Value,
-			},
-		)
+		jt := jsonToken{
+			Type:  strings.ToLower(t.Type.String()),
+			Value: t.Value,
+		}
+		if options.positions {
+			jt.Start = newJSONPosition(t.Start)
+			jt.End = newJSONPosition(t.End)
+		}
+
+		tokenList = append(tokenList, jt)
 	}
 
 	return w.Encode(tokenList)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/pmezard/go-difflib/difflib"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"strings"
 	"testing"
@@ -20,15 +21,15 @@ func TestEncode(t *testing.T) {
 		},
 		{
 			query:    "WHERE id = 99999 AND exists",
-			expected: `[{"type":"keyword","value":"WHERE"},{"type":"whitespace","value":" "},{"type":"name","value":"id"},{"type":"whitespace","value":" "},{"type":"operator","value":"="},{"type":"whitespace","value":" "},{"type":"numberinteger","value":"99999"},{"type":"whitespace","value":" "},{"type":"keyword","value":"AND"},{"type":"whitespace","value":" "},{"type":"name","value":"exists"}]`,
+			expected: `[{"type":"keyword","value":"WHERE"},{"type":"whitespace","value":" "},{"type":"name","value":"id"},{"type":"whitespace","value":" "},{"type":"operator","value":"="},{"type":"whitespace","value":" "},{"type":"numberinteger","value":"99999"},{"type":"whitespace","value":" "},{"type":"keyword","value":"AND"},{"type":"whitespace","value":" "},{"type":"keyword","value":"exists"}]`,
 		},
 		{
 			query:    "WHERE id = 99999\nAND exists",
-			expected: `[{"type":"keyword","value":"WHERE"},{"type":"whitespace","value":" "},{"type":"name","value":"id"},{"type":"whitespace","value":" "},{"type":"operator","value":"="},{"type":"whitespace","value":" "},{"type":"numberinteger","value":"99999"},{"type":"newline","value":"\n"},{"type":"keyword","value":"AND"},{"type":"whitespace","value":" "},{"type":"name","value":"exists"}]`,
+			expected: `[{"type":"keyword","value":"WHERE"},{"type":"whitespace","value":" "},{"type":"name","value":"id"},{"type":"whitespace","value":" "},{"type":"operator","value":"="},{"type":"whitespace","value":" "},{"type":"numberinteger","value":"99999"},{"type":"newline","value":"\n"},{"type":"keyword","value":"AND"},{"type":"whitespace","value":" "},{"type":"keyword","value":"exists"}]`,
 		},
 		{
 			query:    "WHERE id = 99999\tAND exists",
-			expected: `[{"type":"keyword","value":"WHERE"},{"type":"whitespace","value":" "},{"type":"name","value":"id"},{"type":"whitespace","value":" "},{"type":"operator","value":"="},{"type":"whitespace","value":" "},{"type":"numberinteger","value":"99999"},{"type":"whitespace","value":"\t"},{"type":"keyword","value":"AND"},{"type":"whitespace","value":" "},{"type":"name","value":"exists"}]`,
+			expected: `[{"type":"keyword","value":"WHERE"},{"type":"whitespace","value":" "},{"type":"name","value":"id"},{"type":"whitespace","value":" "},{"type":"operator","value":"="},{"type":"whitespace","value":" "},{"type":"numberinteger","value":"99999"},{"type":"whitespace","value":"\t"},{"type":"keyword","value":"AND"},{"type":"whitespace","value":" "},{"type":"keyword","value":"exists"}]`,
 		},
 		{
 			query:    "IF(x IN (1,1,2,3,5,8,13,21), 'A', 'B')",
@@ -92,3 +93,15 @@ func TestEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodePositions(t *testing.T) {
+	var sb strings.Builder
+	tokens, err := GetTokens("SELECT\n1")
+	require.NoError(t, err, "GetTokens")
+	require.NoError(t, Encode(json.NewEncoder(&sb), tokens, EncodeOptionPositions(true)), "Encode")
+
+	expected := `[{"type":"keyword","value":"SELECT","start":{"offset":0,"line":1,"column":1},"end":{"offset":6,"line":1,"column":7}},` +
+		`{"type":"newline","value":"\n","start":{"offset":6,"line":1,"column":7},"end":{"offset":7,"line":2,"column":1}},` +
+		`{"type":"numberinteger","value":"1","start":{"offset":7,"line":2,"column":1},"end":{"offset":8,"line":2,"column":2}}]`
+	assert.Equal(t, expected, strings.TrimSpace(sb.String()))
+}
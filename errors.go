@@ -0,0 +1,90 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// snippetContext is the maximum number of runes shown on each side of the error in a SyntaxError snippet.
+const snippetContext = 40
+
+// SyntaxError is returned by the lexer when it finds input that doesn't match any token rule.
+type SyntaxError struct {
+	Position // where the offending input starts
+
+	Rune    rune   // the offending rune
+	Msg     string // description of the problem, without the position
+	Snippet string // the offending line followed by a line with a caret pointing to the offending rune
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// newSyntaxError returns an error for the input at offset of data, which is found at pos of the whole input. When
+// msg is empty, the error says the input couldn't be parsed.
+func newSyntaxError(data string, offset int, pos Position, msg string) *SyntaxError {
+	r, _ := utf8.DecodeRuneInString(data[offset:])
+	if msg == "" {
+		msg = fmt.Sprintf("could not parse token starting with %q", r)
+	}
+
+	return &SyntaxError{
+		Position: pos,
+		Rune:     r,
+		Msg:      msg,
+		Snippet:  snippet(data, offset),
+	}
+}
+
+// snippet returns the line of data containing offset and a caret line below it pointing to offset. Long lines are
+// trimmed around offset, and only the runes shown are read, so that each error of a long line costs the same.
+func snippet(data string, offset int) string {
+	before := []rune(data[snippetStart(data, offset):offset])
+	var after []rune
+	for _, r := range data[offset:] {
+		if r == '\r' || r == '\n' || len(after) > snippetContext+1 {
+			break
+		}
+		after = append(after, r)
+	}
+
+	var prefix, suffix string
+	if len(before) > snippetContext {
+		before = before[len(before)-snippetContext:]
+		prefix = "..."
+	}
+	if len(after) > snippetContext+1 {
+		after = after[:snippetContext+1]
+		suffix = "..."
+	}
+
+	var caret strings.Builder
+	caret.WriteString(strings.Repeat(" ", len(prefix)))
+	for _, r := range before {
+		// keep tabs so the caret lines up with the source when displayed
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteRune('^')
+
+	return prefix + string(before) + string(after) + suffix + "\n" + caret.String()
+}
+
+// snippetStart returns where the part of the line before offset read by snippet starts: snippetContext runes before
+// offset, and one more telling whether the line is trimmed.
+func snippetStart(data string, offset int) int {
+	start := offset
+	for n := 0; start > 0 && n <= snippetContext; n++ {
+		r, size := utf8.DecodeLastRuneInString(data[:start])
+		if r == '\r' || r == '\n' {
+			break
+		}
+		start -= size
+	}
+	return start
+}
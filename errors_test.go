@@ -0,0 +1,48 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		offset   int
+		expected string
+	}{
+		{"single line", "SELECT ?", 7, "SELECT ?\n       ^"},
+		{"first char", "?", 0, "?\n^"},
+		{"middle line", "SELECT\n\ta ? b\nFROM foo", 10, "\ta ? b\n\t  ^"},
+		{"crlf", "SELECT 1\r\n{x}\r\nFROM foo", 10, "{x}\n^"},
+		{"unicode", "SELECT 'ção' ?", 15, "SELECT 'ção' ?\n             ^"},
+		{
+			"long line",
+			strings.Repeat("a", 50) + "?" + strings.Repeat("b", 50),
+			50,
+			"..." + strings.Repeat("a", 40) + "?" + strings.Repeat("b", 40) + "...\n" + strings.Repeat(" ", 43) + "^",
+		},
+		{
+			"context boundaries",
+			"x\n" + strings.Repeat("a", 40) + "?" + strings.Repeat("b", 40) + "\ny",
+			42,
+			strings.Repeat("a", 40) + "?" + strings.Repeat("b", 40) + "\n" + strings.Repeat(" ", 40) + "^",
+		},
+		{"invalid utf-8", "a\xff?\xfe", 2, "a\ufffd?\ufffd\n  ^"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, snippet(test.data, test.offset))
+		})
+	}
+}
+
+func BenchmarkSnippetLongLine(b *testing.B) {
+	data := strings.Repeat("a", 1<<16)
+	for i := 0; i < b.N; i++ {
+		snippet(data, len(data)/2)
+	}
+}
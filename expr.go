@@ -0,0 +1,912 @@
+package sqlparse
+
+import (
+	"slices"
+	"strings"
+)
+
+// precedenceSubscript binds a subscript, as in a[1], more tightly than any operator.
+const precedenceSubscript = PrecedenceCast + 1
+
+// typedLiteralTypes are the types that may precede a string to make a typed literal, as in DATE '2024-01-31'.
+var typedLiteralTypes = []string{
+	"DATE", "DATETIME", "INTERVAL", "TIME", "TIME WITH TIME ZONE", "TIME WITHOUT TIME ZONE", "TIMESTAMP",
+	"TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITHOUT TIME ZONE",
+}
+
+// intervalUnits are the units that may follow an interval literal, as in INTERVAL '1' DAY.
+var intervalUnits = []string{"YEAR", "MONTH", "WEEK", "DAY", "HOUR", "MINUTE", "SECOND"}
+
+// niladicFunctions are the functions called without parentheses, as in DEFAULT CURRENT_TIMESTAMP.
+var niladicFunctions = []string{
+	"CURRENT_DATE", "CURRENT_ROLE", "CURRENT_SCHEMA", "CURRENT_TIME", "CURRENT_TIMESTAMP", "CURRENT_USER", "LOCALTIME",
+	"LOCALTIMESTAMP", "SESSION_USER", "SYSTEM_USER", "USER",
+}
+
+// reservedFunctions are the reserved keywords that are also function names, as in EXISTS (SELECT ...) or LEFT(s, 1).
+var reservedFunctions = []string{
+	"ALL", "ANY", "DATABASE", "EXISTS", "IF", "INSERT", "JSON_TABLE", "LEFT", "REPEAT", "REPLACE", "RIGHT", "ROW",
+	"SCHEMA", "SOME", "TRUNCATE", "UNNEST", "VALUES",
+}
+
+// dataTypeWords are the words that may follow the name of a type, as in INT UNSIGNED or BIT VARYING.
+var dataTypeWords = []string{"PRECISION", "SIGNED", "UNSIGNED", "VARYING", "ZEROFILL"}
+
+// parseExpr parses an expression. It stops at the first token that can't continue the expression, such as a comma, a
+// closing parenthesis, a clause keyword or an alias.
+func (p *parser) parseExpr() (Expr, error) {
+	return p.parseExprPrec(PrecedenceNone)
+}
+
+// parseExprPrec parses an expression made of the operators binding more tightly than minPrec, following the
+// precedences of Lexer.Precedence. Operators of the same precedence are left associative.
+func (p *parser) parseExprPrec(minPrec int) (Expr, error) {
+	start := p.peek()
+	left, err := p.parsePrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		prec := p.infixPrecedence(p.peek())
+		if prec <= minPrec {
+			return left, nil
+		}
+		if left, err = p.parseInfix(start, left, prec); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// infixPrecedence returns the precedence of the token following an operand, or PrecedenceNone when it ends the
+// expression.
+func (p *parser) infixPrecedence(t Token) int {
+	switch t.Type {
+	case TokenPunctuation:
+		if t.Value == "[" {
+			return precedenceSubscript
+		}
+	case TokenKeyword:
+		// NOT is only a prefix operator, NOT IN and NOT LIKE are keywords of their own
+		if t.Normalized() == "NOT" {
+			return PrecedenceNone
+		}
+		return p.lexer.Precedence(t)
+	case TokenOperator, TokenWildcard:
+		return p.lexer.Precedence(t)
+	}
+	return PrecedenceNone
+}
+
+// parsePrefix parses an operand, with its prefix operators.
+func (p *parser) parsePrefix() (Expr, error) {
+	token := p.peek()
+	next := p.peekAt(1)
+
+	// TRY_CAST and SAFE_CAST are names in most dialects
+	if p.isWord("CAST", "TRY_CAST", "SAFE_CAST") && next.Type == TokenPunctuation && next.Value == "(" {
+		return p.parseCast()
+	}
+	// the functions whose arguments are separated by keywords in the standard
+	if next.Type == TokenPunctuation && next.Value == "(" {
+		switch {
+		case p.isWord("EXTRACT"):
+			return p.parseExtract()
+		case p.isWord("SUBSTRING"):
+			return p.parseSubstring()
+		case p.isWord("TRIM"):
+			return p.parseTrim()
+		case p.isWord("POSITION"):
+			return p.parsePosition()
+		case p.isWord("OVERLAY"):
+			return p.parseOverlay()
+		}
+	}
+
+	switch token.Type {
+	case TokenNumberInteger, TokenNumberFloat, TokenString:
+		p.pos++
+		return &LiteralExpr{Span: p.span(token), Token: token}, nil
+	case TokenParameter:
+		p.pos++
+		return &ParamExpr{Span: p.span(token), Token: token}, nil
+	case TokenWildcard:
+		p.pos++
+		return &WildcardExpr{Span: p.span(token)}, nil
+	case TokenOperator:
+		switch token.Value {
+		case "-", "+", "~":
+			p.pos++
+			operand, err := p.parseExprPrec(PrecedenceExponent)
+			if err != nil {
+				return nil, err
+			}
+			return &UnaryExpr{Span: p.span(token), Operator: token.Value, Operand: operand}, nil
+		}
+	case TokenPunctuation:
+		if token.Value == "(" {
+			return p.parseParen()
+		}
+	case TokenKeyword:
+		keyword := token.Normalized()
+		switch {
+		case keyword == "NULL" || keyword == "TRUE" || keyword == "FALSE":
+			p.pos++
+			return &LiteralExpr{Span: p.span(token), Token: token}, nil
+		case keyword == "NOT":
+			p.pos++
+			operand, err := p.parseExprPrec(PrecedenceNot)
+			if err != nil {
+				return nil, err
+			}
+			return &UnaryExpr{Span: p.span(token), Operator: keyword, Operand: operand}, nil
+		case keyword == "DEFAULT":
+			p.pos++
+			return &DefaultExpr{Span: p.span(token)}, nil
+		case keyword == "CASE":
+			return p.parseCase()
+		case keyword == "ARRAY" && next.Value == "[":
+			return p.parseArray()
+		case slices.Contains(typedLiteralTypes, keyword) && next.Type == TokenString:
+			return p.parseTypedLiteral()
+		case slices.Contains(niladicFunctions, keyword) && next.Value != "(":
+			p.pos++
+			ident := Ident{Span: p.span(token), Name: token.Value}
+			name := ObjectName{Span: ident.Span, Parts: []*Ident{&ident}}
+			return &FuncExpr{Span: ident.Span, Name: &name}, nil
+		}
+	}
+
+	// keywords are function names too, as in COUNT(*) or LEFT(s, 1)
+	isFunc := token.Type == TokenKeyword && next.Type == TokenPunctuation && next.Value == "(" && p.isFuncKeyword(token)
+	if isFunc || p.isIdent(token) {
+		return p.parseNameOrCall()
+	}
+	return nil, p.errorf("expected an expression, found %s", p.describe())
+}
+
+// isFuncKeyword reports whether the keyword token can name a function: a non-reserved keyword, a built-in function or
+// a type, or one of the reservedFunctions. Other reserved keywords, such as DISTINCT or WHERE, can't.
+func (p *parser) isFuncKeyword(token Token) bool {
+	if strings.ContainsAny(token.Value, " \t\r\n") {
+		return false
+	}
+	switch token.Category() {
+	case KeywordFunction, KeywordDataType:
+		return true
+	}
+	return !p.lexer.IsReservedKeyword(token.Value) || slices.Contains(reservedFunctions, token.Normalized())
+}
+
+// parseInfix parses the operator following left, with its right operand. start is the first token of left.
+func (p *parser) parseInfix(start Token, left Expr, prec int) (Expr, error) {
+	token := p.next()
+	var err error
+
+	if token.Type == TokenPunctuation && token.Value == "[" {
+		e := SubscriptExpr{Expr: left}
+		if p.splitSliceColon(); !p.isPunct(":") {
+			if e.Index, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+		p.splitSliceColon()
+		if e.Slice = p.acceptPunct(":"); e.Slice && !p.isPunct("]") {
+			if e.Upper, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		e.Span = p.span(start)
+		return &e, nil
+	}
+	if token.Type == TokenOperator && token.Value == "::" {
+		e := CastExpr{Kind: "::", Expr: left}
+		if e.Type, err = p.parseDataType(); err != nil {
+			return nil, err
+		}
+		e.Span = p.span(start)
+		return &e, nil
+	}
+
+	operator := token.Normalized()
+	switch operator {
+	case "IS NULL", "IS NOT NULL", "ISNULL", "NOTNULL":
+		not := operator == "IS NOT NULL" || operator == "NOTNULL"
+		return &IsExpr{Span: p.span(start), Expr: left, Not: not, Value: "NULL"}, nil
+	case "IS":
+		e := IsExpr{Expr: left, Not: p.acceptKeyword("NOT")}
+		if !p.isWord("NULL", "TRUE", "FALSE", "UNKNOWN") {
+			return nil, p.errorf("expected NULL, TRUE, FALSE or UNKNOWN, found %s", p.describe())
+		}
+		e.Value = strings.ToUpper(p.next().Value)
+		e.Span = p.span(start)
+		return &e, nil
+	case "BETWEEN", "NOT BETWEEN":
+		e := BetweenExpr{Expr: left, Not: operator == "NOT BETWEEN"}
+		if e.Low, err = p.parseExprPrec(PrecedencePredicate); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		if e.High, err = p.parseExprPrec(PrecedencePredicate); err != nil {
+			return nil, err
+		}
+		e.Span = p.span(start)
+		return &e, nil
+	case "IN", "NOT IN":
+		return p.parseIn(start, left, operator == "NOT IN")
+	case "LIKE", "NOT LIKE", "ILIKE", "NOT ILIKE", "SIMILAR TO", "NOT SIMILAR TO":
+		e := LikeExpr{Operator: strings.TrimPrefix(operator, "NOT "), Not: strings.HasPrefix(operator, "NOT "), Expr: left}
+		if e.Pattern, err = p.parseExprPrec(PrecedencePredicate); err != nil {
+			return nil, err
+		}
+		if p.acceptKeyword("ESCAPE") {
+			if e.Escape, err = p.parseExprPrec(PrecedencePredicate); err != nil {
+				return nil, err
+			}
+		}
+		e.Span = p.span(start)
+		return &e, nil
+	}
+
+	e := BinaryExpr{Operator: operator, Left: left}
+	if e.Right, err = p.parseExprPrec(prec); err != nil {
+		return nil, err
+	}
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// splitSliceColon splits the current token when it is a parameter such as :n, which is rather the colon of a slice
+// followed by its upper bound in a subscript, as in a[:n] or a[x::int:n].
+func (p *parser) splitSliceColon() {
+	token := p.peek()
+	if token.Type != TokenParameter || !strings.HasPrefix(token.Value, ":") {
+		return
+	}
+	tokens, err := p.lexer.GetTokens(token.Value[1:])
+	if err != nil || len(tokens) != 1 {
+		return
+	}
+
+	colon := Token{Value: ":", Type: TokenPunctuation, Start: token.Start, End: token.Start.advance(":")}
+	bound := tokens[0]
+	bound.Start, bound.End = colon.End, colon.End.advance(bound.Value)
+	p.tokens = slices.Replace(p.tokens, p.pos, p.pos+1, colon, bound)
+}
+
+// parseParen parses a subquery, a parenthesised expression or a tuple.
+func (p *parser) parseParen() (Expr, error) {
+	start := p.next()
+
+	if p.isKeyword("SELECT", "WITH") {
+		q, err := p.parseQuery()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return &SubqueryExpr{Span: p.span(start), Query: q}, nil
+	}
+
+	exprs, err := p.parseExprList()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	if len(exprs) == 1 {
+		return &ParenExpr{Span: p.span(start), Expr: exprs[0]}, nil
+	}
+	return &TupleExpr{Span: p.span(start), Exprs: exprs}, nil
+}
+
+// parseExprList parses expressions separated by commas.
+func (p *parser) parseExprList() ([]Expr, error) {
+	var exprs []Expr
+	for {
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if !p.acceptPunct(",") {
+			return exprs, nil
+		}
+	}
+}
+
+// parseIn parses the list or the subquery following IN.
+func (p *parser) parseIn(start Token, left Expr, not bool) (Expr, error) {
+	e := InExpr{Expr: left, Not: not}
+	var err error
+
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	if p.isKeyword("SELECT", "WITH") {
+		e.Query, err = p.parseQuery()
+	} else {
+		e.List, err = p.parseExprList()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseCase parses CASE [operand] WHEN condition THEN result ... [ELSE result] END.
+func (p *parser) parseCase() (Expr, error) {
+	start := p.next()
+	var e CaseExpr
+	var err error
+
+	if !p.isKeyword("WHEN", "END") {
+		if e.Operand, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	for p.isKeyword("WHEN") {
+		when := WhenClause{}
+		whenStart := p.next()
+		if when.Condition, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectKeyword("THEN"); err != nil {
+			return nil, err
+		}
+		if when.Result, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		when.Span = p.span(whenStart)
+		e.Whens = append(e.Whens, &when)
+	}
+	if len(e.Whens) == 0 {
+		return nil, p.errorf("expected WHEN, found %s", p.describe())
+	}
+	if p.acceptKeyword("ELSE") {
+		if e.Else, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expectKeyword("END"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseCast parses CAST(expr AS type), and the same with TRY_CAST and SAFE_CAST.
+func (p *parser) parseCast() (Expr, error) {
+	start := p.next()
+	e := CastExpr{Kind: strings.ToUpper(start.Value)}
+	var err error
+
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	if e.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	if e.Type, err = p.parseDataType(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseExtract parses EXTRACT(field FROM expr).
+func (p *parser) parseExtract() (Expr, error) {
+	start := p.next()
+	var e ExtractExpr
+	var err error
+
+	p.pos++ // (
+	if field := p.peek(); field.Type == TokenName || field.Type == TokenKeyword && !strings.ContainsAny(field.Value, " \t\r\n") {
+		p.pos++
+		e.Field = strings.ToUpper(field.Value)
+	} else {
+		return nil, p.errorf("expected a field, found %s", p.describe())
+	}
+	if _, err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	if e.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseSubstring parses SUBSTRING(expr FROM start FOR length), or SUBSTRING(expr, start, length) as a function call.
+func (p *parser) parseSubstring() (Expr, error) {
+	start := p.next()
+	var e SubstringExpr
+	var err error
+
+	p.pos++ // (
+	if e.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if p.isPunct(",") {
+		return p.parseCallRest(start, e.Expr)
+	}
+	if p.acceptKeyword("FROM") {
+		if e.From, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("FOR") {
+		if e.For, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if e.From == nil && e.For == nil {
+		return nil, p.errorf("expected FROM or FOR, found %s", p.describe())
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseTrim parses TRIM([BOTH|LEADING|TRAILING] [chars] FROM expr) and TRIM(BOTH expr), or TRIM(expr[, chars]) as a
+// function call.
+func (p *parser) parseTrim() (Expr, error) {
+	start := p.next()
+	var e TrimExpr
+	var err error
+
+	p.pos++ // (
+	if p.isKeyword("BOTH", "LEADING", "TRAILING") {
+		e.Side = p.next().Normalized()
+	}
+	if !p.isKeyword("FROM") {
+		if e.Expr, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if e.Side == "" && !p.isKeyword("FROM") {
+			return p.parseCallRest(start, e.Expr)
+		}
+	}
+	if p.acceptKeyword("FROM") {
+		e.Chars = e.Expr
+		if e.Expr, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parsePosition parses POSITION(substring IN expr). The substring binds more tightly than IN, as in PostgreSQL.
+func (p *parser) parsePosition() (Expr, error) {
+	start := p.next()
+	var e PositionExpr
+	var err error
+
+	p.pos++ // (
+	if e.Substring, err = p.parseExprPrec(PrecedencePredicate); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("IN"); err != nil {
+		return nil, err
+	}
+	if e.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseOverlay parses OVERLAY(expr PLACING replacement FROM start [FOR length]).
+func (p *parser) parseOverlay() (Expr, error) {
+	start := p.next()
+	var e OverlayExpr
+	var err error
+
+	p.pos++ // (
+	if e.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if err := p.expectWord("PLACING"); err != nil {
+		return nil, err
+	}
+	if e.Placing, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	if e.From, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("FOR") {
+		if e.For, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseCallRest parses the arguments following the first one, already parsed, of a call to the function named by
+// start, as in SUBSTRING(s, 1, 3).
+func (p *parser) parseCallRest(start Token, first Expr) (Expr, error) {
+	ident := Ident{Span: Span{Start: start.Start, End: start.End}, Name: start.Identifier()}
+	e := FuncExpr{Name: &ObjectName{Span: ident.Span, Parts: []*Ident{&ident}}, Args: []Expr{first}}
+	for p.acceptPunct(",") {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		e.Args = append(e.Args, arg)
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseArray parses ARRAY[elem, ...].
+func (p *parser) parseArray() (Expr, error) {
+	start := p.next()
+	var e ArrayExpr
+	var err error
+
+	p.pos++ // [
+	if !p.isPunct("]") {
+		if e.Elems, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectPunct("]"); err != nil {
+		return nil, err
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseTypedLiteral parses a type followed by a string, and the unit of an interval.
+func (p *parser) parseTypedLiteral() (Expr, error) {
+	start := p.next()
+	e := TypedLiteralExpr{Type: start.Normalized(), Value: p.next()}
+	if e.Type == "INTERVAL" && p.isWord(intervalUnits...) {
+		e.Unit = strings.ToUpper(p.next().Value)
+	}
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseNameOrCall parses a column reference, a qualified wildcard such as t.*, or a function call.
+func (p *parser) parseNameOrCall() (Expr, error) {
+	start := p.next()
+	name := ObjectName{Parts: []*Ident{{Span: p.span(start), Name: start.Identifier(), Quoted: start.Type == TokenQuotedName}}}
+
+	for p.isPunct(".") {
+		p.pos++
+		if p.peek().Type == TokenWildcard {
+			name.Span = Span{Start: start.Start, End: p.tokens[p.pos-2].End}
+			p.pos++
+			return &WildcardExpr{Span: p.span(start), Table: &name}, nil
+		}
+		ident, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		name.Parts = append(name.Parts, ident)
+	}
+	name.Span = p.span(start)
+
+	if p.isPunct("(") {
+		return p.parseCall(start, &name)
+	}
+	return &NameExpr{Span: name.Span, Name: &name}, nil
+}
+
+// parseCall parses the arguments of a function call, and the clauses following them.
+func (p *parser) parseCall(start Token, name *ObjectName) (Expr, error) {
+	e := FuncExpr{Name: name}
+	var err error
+
+	p.pos++ // (
+	switch {
+	case p.isPunct(")"):
+	case p.isKeyword("SELECT", "WITH"):
+		// EXISTS (SELECT ...), ANY (SELECT ...)
+		q, err := p.parseQuery()
+		if err != nil {
+			return nil, err
+		}
+		e.Args = []Expr{&SubqueryExpr{Span: q.Span, Query: q}}
+	default:
+		if p.acceptKeyword("DISTINCT") {
+			e.Distinct = true
+		} else {
+			p.acceptKeyword("ALL")
+		}
+		if e.Args, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+		if p.acceptKeyword("ORDER BY") {
+			if e.OrderBy, err = p.parseOrderItems(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	if p.isKeyword("WITHIN") && isKeywordToken(p.peekAt(1), "GROUP") {
+		p.pos += 2
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectKeyword("ORDER BY"); err != nil {
+			return nil, err
+		}
+		if e.WithinGroup, err = p.parseOrderItems(); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("FILTER") {
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectKeyword("WHERE"); err != nil {
+			return nil, err
+		}
+		if e.Filter, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("OVER") {
+		if e.Over, err = p.parseWindowSpec(); err != nil {
+			return nil, err
+		}
+	}
+
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseWindowSpec parses the window following OVER: a window name, or a specification between parentheses.
+func (p *parser) parseWindowSpec() (*WindowSpec, error) {
+	start := p.peek()
+	var w WindowSpec
+	var err error
+
+	if !p.acceptPunct("(") {
+		if w.Name, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+		w.Span = p.span(start)
+		return &w, nil
+	}
+
+	if p.isIdent(p.peek()) {
+		if w.Name, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("PARTITION BY") {
+		if w.PartitionBy, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("ORDER BY") {
+		if w.OrderBy, err = p.parseOrderItems(); err != nil {
+			return nil, err
+		}
+	}
+	if p.isWord("ROWS", "RANGE", "GROUPS") {
+		if w.Frame, err = p.parseWindowFrame(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	w.Span = p.span(start)
+	return &w, nil
+}
+
+// parseWindowFrame parses ROWS|RANGE|GROUPS, followed by a bound or BETWEEN two bounds.
+func (p *parser) parseWindowFrame() (*WindowFrame, error) {
+	start := p.next()
+	f := WindowFrame{Units: strings.ToUpper(start.Value)}
+	var err error
+
+	between := p.acceptKeyword("BETWEEN")
+	if f.Start, err = p.parseFrameBound(); err != nil {
+		return nil, err
+	}
+	if between {
+		if _, err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		if f.End, err = p.parseFrameBound(); err != nil {
+			return nil, err
+		}
+	}
+
+	f.Span = p.span(start)
+	return &f, nil
+}
+
+// parseFrameBound parses UNBOUNDED PRECEDING|FOLLOWING, CURRENT ROW, or offset PRECEDING|FOLLOWING.
+func (p *parser) parseFrameBound() (*FrameBound, error) {
+	start := p.peek()
+	var b FrameBound
+	var err error
+
+	switch {
+	case p.acceptWord("UNBOUNDED"):
+		b.Kind = "UNBOUNDED "
+	case p.acceptWord("CURRENT"):
+		if _, err := p.expectKeyword("ROW"); err != nil {
+			return nil, err
+		}
+		b.Kind = "CURRENT ROW"
+		b.Span = p.span(start)
+		return &b, nil
+	default:
+		if b.Offset, err = p.parseExprPrec(PrecedenceAnd); err != nil {
+			return nil, err
+		}
+	}
+	if !p.isWord("PRECEDING", "FOLLOWING") {
+		return nil, p.errorf("expected PRECEDING or FOLLOWING, found %s", p.describe())
+	}
+	b.Kind += strings.ToUpper(p.next().Value)
+
+	b.Span = p.span(start)
+	return &b, nil
+}
+
+// parseOrderItems parses the items of an ORDER BY, separated by commas.
+func (p *parser) parseOrderItems() ([]*OrderItem, error) {
+	var items []*OrderItem
+	for {
+		item, err := p.parseOrderItem()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if !p.acceptPunct(",") {
+			return items, nil
+		}
+	}
+}
+
+// parseDataType parses a type name, possibly qualified, followed by its modifiers and array dimensions, as in
+// NUMERIC(10, 2), INT UNSIGNED, TIMESTAMP(3) WITH TIME ZONE or TEXT[].
+func (p *parser) parseDataType() (*DataType, error) {
+	start := p.peek()
+	var t DataType
+	var name strings.Builder
+
+	if !isTypeWord(start) {
+		return nil, p.errorf("expected a type, found %s", p.describe())
+	}
+	name.WriteString(typeWord(p.next()))
+	for p.isPunct(".") && isTypeWord(p.peekAt(1)) {
+		p.pos++
+		name.WriteString("." + typeWord(p.next()))
+	}
+
+	words := func() {
+		for p.isWord(dataTypeWords...) {
+			name.WriteString(" " + typeWord(p.next()))
+		}
+	}
+	words()
+	if p.acceptPunct("(") {
+		for {
+			var arg Expr
+			var err error
+			if p.isWord("MAX") && (p.peekAt(1).Value == ")" || p.peekAt(1).Value == ",") {
+				// VARCHAR(MAX) as in SQL Server
+				arg, err = p.parseNameOrCall()
+			} else {
+				arg, err = p.parseExpr()
+			}
+			if err != nil {
+				return nil, err
+			}
+			t.Args = append(t.Args, arg)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		words()
+		// TIMESTAMP(3) WITH TIME ZONE, the words being a single keyword without the modifier
+		if p.isWord("WITH", "WITHOUT") && isWordToken(p.peekAt(1), "TIME") && isWordToken(p.peekAt(2), "ZONE") {
+			name.WriteString(" " + typeWord(p.next()) + " TIME ZONE")
+			p.pos += 2
+		}
+	}
+	for p.isPunct("[") {
+		p.pos++
+		if p.peek().Type == TokenNumberInteger {
+			p.pos++
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		t.ArrayDims++
+	}
+
+	t.Name = name.String()
+	t.Span = p.span(start)
+	return &t, nil
+}
+
+// isTypeWord reports whether the token can be part of a type name. Types are often keywords, such as INT.
+func isTypeWord(t Token) bool {
+	switch t.Type {
+	case TokenName, TokenQuotedName, TokenKeyword:
+		return true
+	}
+	return false
+}
+
+// typeWord returns the word of a type name: unquoted words in uppercase, quoted ones as they are.
+func typeWord(t Token) string {
+	if t.Type == TokenQuotedName {
+		return t.Identifier()
+	}
+	return strings.ToUpper(joinWords(t.Value))
+}
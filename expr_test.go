@@ -0,0 +1,345 @@
+package sqlparse
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// parseExpr parses data as the single column of a SELECT.
+func parseExpr(t *testing.T, l *Lexer, data string) Expr {
+	t.Helper()
+	stmt, err := l.Parse("SELECT " + data)
+	require.NoError(t, err, "Parse")
+	columns := stmt.(*Query).Body.(*Select).Columns
+	require.Len(t, columns, 1, "Columns")
+	require.Nil(t, columns[0].Alias, "Alias")
+	return columns[0].Expr
+}
+
+// sexpr renders an expression as an S-expression showing its grouping, with leaves as their source text.
+func sexpr(data string, e Expr) string {
+	list := func(op string, exprs ...Expr) string {
+		parts := []string{op}
+		for _, expr := range exprs {
+			if expr != nil {
+				parts = append(parts, sexpr(data, expr))
+			}
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	}
+	clauses := func(op string, e Expr, clauses ...any) string {
+		s := "(" + op + " " + sexpr(data, e)
+		for i := 0; i < len(clauses); i += 2 {
+			if clause, _ := clauses[i+1].(Expr); clause != nil {
+				s += " " + clauses[i].(string) + " " + sexpr(data, clause)
+			}
+		}
+		return s + ")"
+	}
+	not := func(not bool, op string) string {
+		if not {
+			return "NOT " + op
+		}
+		return op
+	}
+
+	switch e := e.(type) {
+	case *UnaryExpr:
+		return list(e.Operator, e.Operand)
+	case *BinaryExpr:
+		return list(e.Operator, e.Left, e.Right)
+	case *BetweenExpr:
+		return list(not(e.Not, "BETWEEN"), e.Expr, e.Low, e.High)
+	case *InExpr:
+		if e.Query != nil {
+			return list(not(e.Not, "IN"), e.Expr) + " " + e.Query.Text(data)
+		}
+		return list(not(e.Not, "IN"), append([]Expr{e.Expr}, e.List...)...)
+	case *LikeExpr:
+		if e.Escape != nil {
+			return list(not(e.Not, e.Operator), e.Expr, e.Pattern) + " ESCAPE " + sexpr(data, e.Escape)
+		}
+		return list(not(e.Not, e.Operator), e.Expr, e.Pattern)
+	case *IsExpr:
+		return list("IS "+not(e.Not, e.Value), e.Expr)
+	case *CaseExpr:
+		exprs := []Expr{e.Operand}
+		for _, when := range e.Whens {
+			exprs = append(exprs, when.Condition, when.Result)
+		}
+		return list("CASE", append(exprs, e.Else)...)
+	case *CastExpr:
+		return fmt.Sprintf("(%s %s %s)", e.Kind, sexpr(data, e.Expr), e.Type.Name)
+	case *SubscriptExpr:
+		if e.Slice {
+			bound := func(e Expr) string {
+				if e == nil {
+					return "_"
+				}
+				return sexpr(data, e)
+			}
+			return fmt.Sprintf("([:] %s %s %s)", sexpr(data, e.Expr), bound(e.Index), bound(e.Upper))
+		}
+		return list("[]", e.Expr, e.Index)
+	case *ExtractExpr:
+		return fmt.Sprintf("(EXTRACT %s %s)", e.Field, sexpr(data, e.Expr))
+	case *SubstringExpr:
+		return clauses("SUBSTRING", e.Expr, "FROM", e.From, "FOR", e.For)
+	case *TrimExpr:
+		return clauses(strings.TrimSpace("TRIM "+e.Side), e.Expr, "CHARS", e.Chars)
+	case *PositionExpr:
+		return clauses("POSITION", e.Substring, "IN", e.Expr)
+	case *OverlayExpr:
+		return clauses("OVERLAY", e.Expr, "PLACING", e.Placing, "FROM", e.From, "FOR", e.For)
+	case *ParenExpr:
+		return sexpr(data, e.Expr)
+	case *FuncExpr:
+		return list(e.Name.String(), e.Args...)
+	}
+	return e.Pos().Text(data)
+}
+
+func TestParseExprPrecedence(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		expr     string
+		expected string
+	}{
+		{dialect: ANSI, expr: "1 + 2 * 3 - 4", expected: "(- (+ 1 (* 2 3)) 4)"},
+		{dialect: ANSI, expr: "(1 + 2) * 3 / 4 % 5", expected: "(% (/ (* (+ 1 2) 3) 4) 5)"},
+		{dialect: ANSI, expr: "a OR b AND NOT c = d", expected: "(OR a (AND b (NOT (= c d))))"},
+		{dialect: ANSI, expr: "NOT a AND b", expected: "(AND (NOT a) b)"},
+		{dialect: ANSI, expr: "-a * -b", expected: "(* (- a) (- b))"},
+		{dialect: ANSI, expr: "a || b = c", expected: "(= (|| a b) c)"},
+		{dialect: ANSI, expr: "a = b IS NOT NULL", expected: "(IS NOT NULL (= a b))"},
+		{dialect: ANSI, expr: "a IS NOT DISTINCT FROM b + 1", expected: "(IS NOT DISTINCT FROM a (+ b 1))"},
+		{dialect: ANSI, expr: "a IS NOT TRUE", expected: "(IS NOT TRUE a)"},
+		{dialect: ANSI, expr: "a BETWEEN 1 + 1 AND b * 2 AND c", expected: "(AND (BETWEEN a (+ 1 1) (* b 2)) c)"},
+		{dialect: ANSI, expr: "a NOT BETWEEN 1 AND 2", expected: "(NOT BETWEEN a 1 2)"},
+		{dialect: ANSI, expr: "a IN (1, b + 1) OR a NOT IN (SELECT c FROM d)", expected: "(OR (IN a 1 (+ b 1)) (NOT IN a) SELECT c FROM d)"},
+		{dialect: ANSI, expr: "a LIKE 'x!%' ESCAPE '!' AND b NOT ILIKE c || '%'", expected: "(AND (LIKE a 'x!%') ESCAPE '!' (NOT ILIKE b (|| c '%')))"},
+		{dialect: ANSI, expr: "a NOT SIMILAR TO 'x'", expected: "(NOT SIMILAR TO a 'x')"},
+		{dialect: PostgreSQL, expr: "-2 ^ 2 * 3", expected: "(* (^ (- 2) 2) 3)"},
+		{dialect: PostgreSQL, expr: "-a::int + b::numeric(10, 2)", expected: "(+ (- (:: a INT)) (:: b NUMERIC))"},
+		{dialect: PostgreSQL, expr: "d->'a'->>'b' = 'c'", expected: "(= (->> (-> d 'a') 'b') 'c')"},
+		{dialect: PostgreSQL, expr: "a[1][b + 1]::text", expected: "(:: ([] ([] a 1) (+ b 1)) TEXT)"},
+		{dialect: PostgreSQL, expr: "j['k'] @> '{}' AND x ~* 'y'", expected: "(AND (@> ([] j 'k') '{}') (~* x 'y'))"},
+		{dialect: ANSI, expr: "a[1] + b[c][2]", expected: "(+ ([] a 1) ([] ([] b c) 2))"},
+		{dialect: ANSI, expr: "a[1:2] || a[lo + 1:hi]", expected: "(|| ([:] a 1 2) ([:] a (+ lo 1) hi))"},
+		{dialect: ANSI, expr: "a[:n][2:][:]", expected: "([:] ([:] ([:] a _ n) 2 _) _ _)"},
+		{dialect: PostgreSQL, expr: "a[x::int:3] = b[ : 2]", expected: "(= ([:] a (:: x INT) 3) ([:] b _ 2))"},
+		{dialect: ANSI, expr: "b::int[] = ARRAY[1, 2]", expected: "(= (:: b INT) ARRAY[1, 2])"},
+		{dialect: MySQL, expr: "a || b && c", expected: "(|| a (&& b c))"},
+		{dialect: MySQL, expr: "a DIV 2 MOD 3", expected: "(MOD (DIV a 2) 3)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			e := parseExpr(t, NewLexer(test.dialect), test.expr)
+			assert.Equal(t, test.expected, sexpr("SELECT "+test.expr, e))
+			assert.Equal(t, test.expr, e.Pos().Text("SELECT "+test.expr), "Span")
+		})
+	}
+}
+
+func TestParseExprNodes(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		expr     string
+		expected string
+	}{
+		{dialect: ANSI, expr: "CASE WHEN a > 1 THEN 'x' WHEN b THEN c + 1 ELSE NULL END", expected: "(CASE (> a 1) 'x' b (+ c 1) NULL)"},
+		{dialect: ANSI, expr: "CASE a WHEN 1 THEN 2 END", expected: "(CASE a 1 2)"},
+		{dialect: ANSI, expr: "CAST(a + 1 AS character varying(10))", expected: "(CAST (+ a 1) CHARACTER VARYING)"},
+		{dialect: Snowflake, expr: "TRY_CAST(a AS int)", expected: "(TRY_CAST a INT)"},
+		{dialect: ANSI, expr: "coalesce(a, upper(b), 'c')", expected: "(coalesce a (upper b) 'c')"},
+		{dialect: ANSI, expr: "s.f(1)", expected: "(s.f 1)"},
+		{dialect: ANSI, expr: "EXISTS (SELECT 1)", expected: "(EXISTS SELECT 1)"},
+		{dialect: ANSI, expr: "(a, b)", expected: "(a, b)"},
+		{dialect: ANSI, expr: "(SELECT max(a) FROM t)", expected: "(SELECT max(a) FROM t)"},
+		{dialect: ANSI, expr: "EXTRACT(YEAR FROM d + 1)", expected: "(EXTRACT YEAR (+ d 1))"},
+		{dialect: PostgreSQL, expr: "extract(epoch FROM d)", expected: "(EXTRACT EPOCH d)"},
+		{dialect: PostgreSQL, expr: "extract(year FROM d)", expected: "(EXTRACT YEAR d)"},
+		{dialect: ANSI, expr: "SUBSTRING(s FROM 1 FOR 3)", expected: "(SUBSTRING s FROM 1 FOR 3)"},
+		{dialect: ANSI, expr: "SUBSTRING(s FOR n - 1)", expected: "(SUBSTRING s FOR (- n 1))"},
+		{dialect: MySQL, expr: "substring(s, 1, 3)", expected: "(substring s 1 3)"},
+		{dialect: ANSI, expr: "TRIM(BOTH ' ' FROM s)", expected: "(TRIM BOTH s CHARS ' ')"},
+		{dialect: ANSI, expr: "TRIM(LEADING FROM s)", expected: "(TRIM LEADING s)"},
+		{dialect: ANSI, expr: "TRIM('x' FROM s)", expected: "(TRIM s CHARS 'x')"},
+		{dialect: PostgreSQL, expr: "trim(trailing s)", expected: "(TRIM TRAILING s)"},
+		{dialect: ANSI, expr: "TRIM(s)", expected: "(TRIM s)"},
+		{dialect: BigQuery, expr: "TRIM(s, 'x')", expected: "(TRIM s 'x')"},
+		{dialect: ANSI, expr: "POSITION('a' IN s)", expected: "(POSITION 'a' IN s)"},
+		{dialect: PostgreSQL, expr: "position('a' || b IN s)", expected: "(POSITION (|| 'a' b) IN s)"},
+		{dialect: ANSI, expr: "OVERLAY(s PLACING 'ab' FROM 2 FOR 3)", expected: "(OVERLAY s PLACING 'ab' FROM 2 FOR 3)"},
+		{dialect: ANSI, expr: "OVERLAY(s PLACING 'ab' FROM 2)", expected: "(OVERLAY s PLACING 'ab' FROM 2)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			e := parseExpr(t, NewLexer(test.dialect), test.expr)
+			assert.Equal(t, test.expected, sexpr("SELECT "+test.expr, e))
+			assert.Equal(t, test.expr, e.Pos().Text("SELECT "+test.expr), "Span")
+		})
+	}
+}
+
+func TestParseFuncExpr(t *testing.T) {
+	const data = "SELECT string_agg(DISTINCT a, ',' ORDER BY a DESC) FILTER (WHERE a IS NOT NULL) " +
+		"OVER (w PARTITION BY b, c ORDER BY d ROWS BETWEEN UNBOUNDED PRECEDING AND 2 FOLLOWING)"
+	stmt, err := Parse(data)
+	require.NoError(t, err, "Parse")
+	require.IsType(t, &FuncExpr{}, stmt.(*Query).Body.(*Select).Columns[0].Expr)
+	e := stmt.(*Query).Body.(*Select).Columns[0].Expr.(*FuncExpr)
+
+	assert.Equal(t, "string_agg", e.Name.String(), "Name")
+	assert.True(t, e.Distinct, "Distinct")
+	require.Len(t, e.Args, 2, "Args")
+	require.Len(t, e.OrderBy, 1, "OrderBy")
+	assert.Equal(t, "DESC", e.OrderBy[0].Direction, "Direction")
+	assert.Equal(t, "a IS NOT NULL", e.Filter.Pos().Text(data), "Filter")
+
+	require.NotNil(t, e.Over, "Over")
+	assert.Equal(t, "w", e.Over.Name.Name, "Name")
+	assert.Len(t, e.Over.PartitionBy, 2, "PartitionBy")
+	assert.Len(t, e.Over.OrderBy, 1, "OrderBy")
+	require.NotNil(t, e.Over.Frame, "Frame")
+	assert.Equal(t, "ROWS", e.Over.Frame.Units, "Units")
+	assert.Equal(t, "UNBOUNDED PRECEDING", e.Over.Frame.Start.Kind, "Start")
+	assert.Equal(t, "FOLLOWING", e.Over.Frame.End.Kind, "End")
+	assert.Equal(t, "2", e.Over.Frame.End.Offset.Pos().Text(data), "Offset")
+
+	stmt, err = Parse("SELECT percentile_cont(0.5) WITHIN GROUP (ORDER BY a), count(*) OVER w, rank() OVER ()")
+	require.NoError(t, err, "Parse")
+	columns := stmt.(*Query).Body.(*Select).Columns
+	assert.Len(t, columns[0].Expr.(*FuncExpr).WithinGroup, 1, "WithinGroup")
+	assert.IsType(t, &WildcardExpr{}, columns[1].Expr.(*FuncExpr).Args[0], "Args")
+	assert.Equal(t, "w", columns[1].Expr.(*FuncExpr).Over.Name.Name, "Over")
+	assert.NotNil(t, columns[2].Expr.(*FuncExpr).Over, "Over")
+
+	// reserved keywords naming functions
+	stmt, err = NewLexer(MySQL).Parse("SELECT left(a, 1), if(b, 1, 2), replace(c, 'x', 'y'), char(65), d = ANY (SELECT 1)")
+	require.NoError(t, err, "Parse")
+	columns = stmt.(*Query).Body.(*Select).Columns
+	var names []string
+	for _, column := range columns[:4] {
+		require.IsType(t, &FuncExpr{}, column.Expr)
+		names = append(names, column.Expr.(*FuncExpr).Name.String())
+	}
+	assert.Equal(t, []string{"left", "if", "replace", "char"}, names, "Names")
+}
+
+func TestParseExprLeaves(t *testing.T) {
+	const data = "SELECT t.*, s.t.a, \"b\", 1.5, 'c', $1, TRUE, DATE '2024-01-31', INTERVAL '1' DAY, ARRAY[1, 2], CURRENT_DATE"
+	stmt, err := NewLexer(PostgreSQL).Parse(data)
+	require.NoError(t, err, "Parse")
+	columns := stmt.(*Query).Body.(*Select).Columns
+	require.Len(t, columns, 11, "Columns")
+
+	require.IsType(t, &WildcardExpr{}, columns[0].Expr)
+	assert.Equal(t, "t", columns[0].Expr.(*WildcardExpr).Table.String(), "Table")
+	require.IsType(t, &NameExpr{}, columns[1].Expr)
+	assert.Equal(t, "s.t.a", columns[1].Expr.(*NameExpr).Name.String(), "Name")
+	require.IsType(t, &NameExpr{}, columns[2].Expr)
+	assert.True(t, columns[2].Expr.(*NameExpr).Name.Parts[0].Quoted, "Quoted")
+	for _, column := range columns[3:7] {
+		if column.Expr.Pos().Text(data) == "$1" {
+			assert.IsType(t, &ParamExpr{}, column.Expr)
+		} else {
+			assert.IsType(t, &LiteralExpr{}, column.Expr)
+		}
+	}
+
+	require.IsType(t, &TypedLiteralExpr{}, columns[7].Expr)
+	assert.Equal(t, "DATE", columns[7].Expr.(*TypedLiteralExpr).Type, "Type")
+	require.IsType(t, &TypedLiteralExpr{}, columns[8].Expr)
+	assert.Equal(t, "DAY", columns[8].Expr.(*TypedLiteralExpr).Unit, "Unit")
+	require.IsType(t, &ArrayExpr{}, columns[9].Expr)
+	assert.Len(t, columns[9].Expr.(*ArrayExpr).Elems, 2, "Elems")
+	require.IsType(t, &FuncExpr{}, columns[10].Expr)
+	assert.Nil(t, columns[10].Expr.(*FuncExpr).Args, "Args")
+}
+
+func TestParseSubscripts(t *testing.T) {
+	const data = "SELECT a[1], a[1:2] FROM t"
+	columns := parseQuery(t, data).Body.(*Select).Columns
+	require.Len(t, columns, 2, "Columns")
+	for _, column := range columns {
+		assert.Nil(t, column.Alias, "Alias")
+		require.IsType(t, &SubscriptExpr{}, column.Expr)
+	}
+	assert.False(t, columns[0].Expr.(*SubscriptExpr).Slice, "Slice")
+	e := columns[1].Expr.(*SubscriptExpr)
+	assert.True(t, e.Slice, "Slice")
+	assert.Equal(t, "2", e.Upper.Pos().Text(data), "Upper")
+
+	// brackets quote names in SQL Server
+	columns = parseQuery(t, "SELECT a [b] FROM t").Body.(*Select).Columns
+	assert.IsType(t, &SubscriptExpr{}, columns[0].Expr)
+	stmt, err := NewLexer(SQLServer).Parse("SELECT a [b] FROM t")
+	require.NoError(t, err, "Parse")
+	assert.Equal(t, "b", stmt.(*Query).Body.(*Select).Columns[0].Alias.Name, "Alias")
+}
+
+func TestParseDataType(t *testing.T) {
+	tests := []struct {
+		dialect   Dialect
+		expr      string
+		name      string
+		args      int
+		arrayDims int
+	}{
+		{dialect: PostgreSQL, expr: "a::double precision", name: "DOUBLE PRECISION"},
+		{dialect: PostgreSQL, expr: "a::timestamp with time zone", name: "TIMESTAMP WITH TIME ZONE"},
+		{dialect: PostgreSQL, expr: "a::public.\"Mood\"[][]", name: "PUBLIC.Mood", arrayDims: 2},
+		{dialect: MySQL, expr: "CAST(a AS decimal(10, 2) unsigned)", name: "DECIMAL UNSIGNED", args: 2},
+		{dialect: PostgreSQL, expr: "a::timestamp(3) with time zone", name: "TIMESTAMP WITH TIME ZONE", args: 1},
+		{dialect: PostgreSQL, expr: "CAST(a AS time(0) WITHOUT TIME ZONE[])", name: "TIME WITHOUT TIME ZONE", args: 1, arrayDims: 1},
+		{dialect: SQLServer, expr: "CAST(a AS nvarchar(max))", name: "NVARCHAR", args: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			e := parseExpr(t, NewLexer(test.dialect), test.expr)
+			require.IsType(t, &CastExpr{}, e)
+			dataType := e.(*CastExpr).Type
+			assert.Equal(t, test.name, dataType.Name, "Name")
+			assert.Len(t, dataType.Args, test.args, "Args")
+			assert.Equal(t, test.arrayDims, dataType.ArrayDims, "ArrayDims")
+		})
+	}
+}
+
+func TestParseExprErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		msg  string
+	}{
+		{expr: "a +", msg: "expected an expression, found end of input"},
+		{expr: "a BETWEEN 1 OR 2", msg: `expected AND, found "OR"`},
+		{expr: "CASE END", msg: `expected WHEN, found "END"`},
+		{expr: "CASE WHEN a THEN b", msg: "expected END, found end of input"},
+		{expr: "CAST(a int)", msg: `expected AS, found "int"`},
+		{expr: "a IS 1", msg: `expected NULL, TRUE, FALSE or UNKNOWN, found "1"`},
+		{expr: "f(a", msg: `expected ")", found end of input`},
+		{expr: "a, WHERE(b)", msg: `expected an expression, found "WHERE"`},
+		{expr: "SELECT(1)", msg: `expected an expression, found "SELECT"`},
+		{expr: "EXTRACT(1 FROM d)", msg: `expected a field, found "1"`},
+		{expr: "SUBSTRING(s)", msg: `expected FROM or FOR, found ")"`},
+		{expr: "POSITION('a', s)", msg: `expected IN, found ","`},
+		{expr: "OVERLAY(s FROM 1)", msg: `expected PLACING, found "FROM"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			_, err := Parse("SELECT " + test.expr)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, test.msg, syntaxErr.Msg, "Msg")
+		})
+	}
+}
@@ -16,6 +16,8 @@ func FormatOptionFromBreakCount(value int) FormatOption {
 		f.fromBreakCount = value
 	}
 }
+
+// FormatOptionRemoveComments removes line and block comments from the query. Optimizer hints are kept.
 func FormatOptionRemoveComments(value bool) FormatOption {
 	return func(f *formatOptionList) {
 		f.removeComments = value
@@ -37,6 +39,7 @@ type formatOptionList struct {
 	parenthesisIdented []bool
 	writtenInThisLine  bool
 	lastWrittenToken   *Token
+	commentRemoved     bool // whether comments were removed since the last written token
 	spaceQueued        string
 	buf                strings.Builder
 }
@@ -80,9 +83,17 @@ func (f *formatOptionList) writeLinebreak() {
 func (f *formatOptionList) writeToken(tokens []Token, pos int) {
 	tokenType := tokens[pos].Type
 	tokenValue := tokens[pos].Value
-	if tokenType == TokenComment && f.removeComments {
+	keyword := tokens[pos].Normalized()
+	if (tokenType == TokenComment || tokenType == TokenCommentBlock) && f.removeComments {
+		f.commentRemoved = true
+		return
+	}
+	// a removed comment leaves the whitespace on both of its sides, keep only the first one
+	isSpace := func(t TokenType) bool { return t == TokenWhitespace || t == TokenNewline }
+	if f.commentRemoved && isSpace(tokenType) && (f.lastWrittenToken == nil || isSpace(f.lastWrittenToken.Type)) {
 		return
 	}
+	f.commentRemoved = false
 
 	if f.reident {
 		if tokenValue == "(" {
@@ -99,7 +110,7 @@ func (f *formatOptionList) writeToken(tokens []Token, pos int) {
 					break
 				}
 			}
-			if nextKeywordToken != nil && nextKeywordToken.Value == "SELECT" {
+			if nextKeywordToken != nil && nextKeywordToken.Normalized() == "SELECT" {
 				shouldIdent = true
 			}
 
@@ -137,7 +148,7 @@ func (f *formatOptionList) writeToken(tokens []Token, pos int) {
 		if tokenType == TokenComment {
 			f.writeLinebreak()
 		}
-		if tokenValue == "SELECT" {
+		if keyword == "SELECT" {
 			if len(f.parenthesisIdented) > 0 {
 				f.writeLinebreak()
 			} else {
@@ -155,7 +166,7 @@ func (f *formatOptionList) writeToken(tokens []Token, pos int) {
 			}
 		}
 
-		if tokenType == TokenName {
+		if tokenType == TokenName || tokenType == TokenQuotedName {
 			// if it's a CTE name, the next non-whitespace tokens are always "AS", "(" and "SELECT"
 			var nextTokens []Token
 			for i := pos + 1; i < len(tokens); i++ {
@@ -167,17 +178,17 @@ func (f *formatOptionList) writeToken(tokens []Token, pos int) {
 				}
 			}
 			if len(nextTokens) == 3 {
-				if nextTokens[0].Value == "AS" && nextTokens[1].Value == "(" && nextTokens[2].Value == "SELECT" {
+				if nextTokens[0].Normalized() == "AS" && nextTokens[1].Value == "(" && nextTokens[2].Normalized() == "SELECT" {
 					f.writeLinebreak()
 				}
 			}
 		}
 
-		if tokenValue == "FROM" {
+		if keyword == "FROM" {
 			// if we have more than X tokens since the select, break the line
 			var tokensSinceSelect int
 			for i := pos - 1; i >= 0; i-- {
-				if tokens[i].Value == "SELECT" {
+				if tokens[i].Normalized() == "SELECT" {
 					break
 				}
 				if tokens[i].Type != TokenWhitespace && tokens[i].Type != TokenNewline {
@@ -190,14 +201,18 @@ func (f *formatOptionList) writeToken(tokens []Token, pos int) {
 			}
 		}
 
-		if tokenValue == "WHERE" || tokenValue == "ORDER BY" || tokenValue == "GROUP BY" || tokenValue == "UNION ALL" || tokenValue == "LEFT OUTER JOIN" {
+		if keyword == "WHERE" || keyword == "ORDER BY" || keyword == "GROUP BY" || keyword == "UNION ALL" || keyword == "LEFT OUTER JOIN" {
 			f.writeLinebreak()
 		}
 
 		tokenValue = strings.TrimSpace(tokenValue)
+		if tokenType == TokenKeyword {
+			// compound keywords may span lines, as in "ORDER\n  BY"
+			tokenValue = joinWords(tokenValue)
+		}
 	}
 
-	if f.uppercaseKeywords && tokenType == TokenKeyword {
+	if f.uppercaseKeywords && (tokenType == TokenKeyword || tokenType == TokenKeywordCTE) {
 		tokenValue = strings.ToUpper(tokenValue)
 	}
 
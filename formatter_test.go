@@ -79,6 +79,21 @@ func TestFormat(t *testing.T) {
 			expected: "WITH\ncomplicated AS (\n  SELECT some_id AS id, IF(x IN (1,1,2,3,5,8,13,21), 'A', 'B') AS something\n  FROM data_source\n  WHERE i = 9999999999 AND created_at >= DATE('2024-01-01')\n  GROUP BY some_id, IF(x IN (1,1,2,3,5,8,13,21), 'A', 'B'), created_at\n)\nSELECT *\nFROM complicated",
 			options:  []FormatOption{FormatOptionReident(true)},
 		},
+		{
+			query:    "SELECT /*+ INDEX(foo foo_idx) */ * /* all\ncolumns */ FROM foo",
+			expected: "SELECT /*+ INDEX(foo foo_idx) */ * FROM foo",
+			options:  []FormatOption{FormatOptionRemoveComments(true)},
+		},
+		{
+			query:    "/* header */ SELECT a, /* b */\n  /* c */ d FROM foo",
+			expected: "SELECT a, d FROM foo",
+			options:  []FormatOption{FormatOptionRemoveComments(true)},
+		},
+		{
+			query:    "SELECT /* all columns */ * FROM foo",
+			expected: "SELECT /* all columns */ * FROM foo",
+			options:  []FormatOption{FormatOptionReident(true), FormatOptionFromBreakCount(3)},
+		},
 		{
 			query:    "SELECT \n * FROM foo",
 			expected: `SELECT * FROM foo`,
@@ -94,6 +109,31 @@ func TestFormat(t *testing.T) {
 			expected: `SELECT * FROM foo`,
 			options:  []FormatOption{FormatOptionUppercaseKeywords(true)},
 		},
+		{
+			query:    "select \"order\", `from` from foo",
+			expected: "SELECT \"order\", `from` FROM foo",
+			options:  []FormatOption{FormatOptionUppercaseKeywords(true)},
+		},
+		{
+			query:    "select foos, bars from foo_list where a = 1 group by foos order\n  by bars",
+			expected: "select foos, bars\nfrom foo_list\nwhere a = 1\ngroup by foos\norder by bars",
+			options:  []FormatOption{FormatOptionReident(true)},
+		},
+		{
+			query:    "select * from foo order by bar",
+			expected: "SELECT * FROM foo ORDER BY bar",
+			options:  []FormatOption{FormatOptionUppercaseKeywords(true)},
+		},
+		{
+			query:    "with a as (select 1) select * from a",
+			expected: "WITH a AS (SELECT 1) SELECT * FROM a",
+			options:  []FormatOption{FormatOptionUppercaseKeywords(true)},
+		},
+		{
+			query:    `WITH "my cte" AS (SELECT foos, bars FROM foo_list) SELECT * FROM "my cte"`,
+			expected: "WITH\n\"my cte\" AS (\n  SELECT foos, bars\n  FROM foo_list\n)\nSELECT * FROM \"my cte\"",
+			options:  []FormatOption{FormatOptionReident(true), FormatOptionFromBreakCount(3)},
+		},
 	}
 
 	for _, test := range tests {
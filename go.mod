@@ -1,6 +1,6 @@
 module github.com/ipkgs/sqlparse
 
-go 1.22.0
+go 1.23.0
 
 require (
 	github.com/pmezard/go-difflib v1.0.0
@@ -0,0 +1,62 @@
+package sqlparse
+
+import (
+	"iter"
+	"slices"
+)
+
+// Tokens returns an iterator over the tokens of data. It yields a *SyntaxError and stops when part of data can't be
+// matched by any rule. Unlike GetTokens, no token list is built, so stopping early costs nothing.
+//
+//	for token, err := range lexer.Tokens(query) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (l *Lexer) Tokens(data string) iter.Seq2[Token, error] {
+	return l.newStringScanner(data).All()
+}
+
+// Tokens returns an iterator over the tokens of data using the default lexer, see Lexer.Tokens.
+func Tokens(data string) iter.Seq2[Token, error] {
+	return defaultLexer().Tokens(data)
+}
+
+// All returns an iterator over the remaining tokens of the Scanner. The error found by the Scanner, if any, is
+// yielded last with an empty token.
+func (s *Scanner) All() iter.Seq2[Token, error] {
+	return func(yield func(Token, error) bool) {
+		for s.Scan() {
+			if !yield(s.Token(), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield(Token{}, err)
+		}
+	}
+}
+
+// Skip returns an iterator over the tokens of seq whose type is not one of types. Errors are always yielded.
+func Skip(seq iter.Seq2[Token, error], types ...TokenType) iter.Seq2[Token, error] {
+	return func(yield func(Token, error) bool) {
+		for token, err := range seq {
+			if err == nil && slices.Contains(types, token.Type) {
+				continue
+			}
+			if !yield(token, err) {
+				return
+			}
+		}
+	}
+}
+
+// SkipTrivia returns an iterator over the tokens of seq that are not whitespace, newlines or comments. Optimizer
+// hints are kept.
+func SkipTrivia(seq iter.Seq2[Token, error]) iter.Seq2[Token, error] {
+	return Skip(seq, triviaTypes...)
+}
+
+// triviaTypes are the token types that don't change the meaning of a query
+var triviaTypes = []TokenType{TokenWhitespace, TokenNewline, TokenComment, TokenCommentBlock}
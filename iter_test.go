@@ -0,0 +1,66 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestTokens(t *testing.T) {
+	const query = "SELECT a, b\nFROM foo -- comment\nWHERE a = 1"
+	expected, err := GetTokens(query)
+	require.NoError(t, err, "GetTokens")
+
+	var tokens []Token
+	for token, err := range Tokens(query) {
+		require.NoError(t, err)
+		tokens = append(tokens, token)
+	}
+	assert.Equal(t, expected, tokens)
+}
+
+func TestTokensStopEarly(t *testing.T) {
+	var values []string
+	for token, err := range Tokens("SELECT a FROM foo WHERE ?") {
+		require.NoError(t, err)
+		if token.Value == "FROM" {
+			break
+		}
+		values = append(values, token.Value)
+	}
+	assert.Equal(t, []string{"SELECT", " ", "a", " "}, values)
+}
+
+func TestTokensError(t *testing.T) {
+	var values []string
+	var lastErr error
+	for token, err := range SkipTrivia(Tokens("SELECT a\nWHERE { = 1")) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		values = append(values, token.Value)
+	}
+	assert.Equal(t, []string{"SELECT", "a", "WHERE"}, values)
+
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, lastErr, &syntaxErr)
+	assert.Equal(t, Position{Offset: 15, Line: 2, Column: 7}, syntaxErr.Position)
+}
+
+func TestSkip(t *testing.T) {
+	var values []string
+	for token, err := range Skip(NewScanner(strings.NewReader("SELECT a, b -- all\nFROM foo")).All(), TokenWhitespace, TokenPunctuation) {
+		require.NoError(t, err)
+		values = append(values, token.Value)
+	}
+	assert.Equal(t, []string{"SELECT", "a", "b", "-- all\n", "FROM", "foo"}, values)
+
+	values = nil
+	for token, err := range SkipTrivia(Tokens("SELECT a, b -- all\nFROM foo")) {
+		require.NoError(t, err)
+		values = append(values, token.Value)
+	}
+	assert.Equal(t, []string{"SELECT", "a", ",", "b", "FROM", "foo"}, values)
+}
@@ -0,0 +1,180 @@
+package sqlparse
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// reservedKeywords are the reserved words of the SQL:2016 standard, which can't be used as names without quoting them.
+var reservedKeywords = []string{
+	"ABS", "ACOS", "ALL", "ALLOCATE", "ALTER", "AND", "ANY", "ARE", "ARRAY", "ARRAY_AGG", "ARRAY_MAX_CARDINALITY", "AS",
+	"ASENSITIVE", "ASIN", "ASYMMETRIC", "AT", "ATAN", "ATOMIC", "AUTHORIZATION", "AVG",
+	"BEGIN", "BEGIN_FRAME", "BEGIN_PARTITION", "BETWEEN", "BIGINT", "BINARY", "BLOB", "BOOLEAN", "BOTH", "BY",
+	"CALL", "CALLED", "CARDINALITY", "CASCADED", "CASE", "CAST", "CEIL", "CEILING", "CHAR", "CHAR_LENGTH", "CHARACTER",
+	"CHARACTER_LENGTH", "CHECK", "CLASSIFIER", "CLOB", "CLOSE", "COALESCE", "COLLATE", "COLLECT", "COLUMN", "COMMIT",
+	"CONDITION", "CONNECT", "CONSTRAINT", "CONTAINS", "CONVERT", "COPY", "CORR", "CORRESPONDING", "COS", "COSH",
+	"COUNT", "COVAR_POP", "COVAR_SAMP", "CREATE", "CROSS", "CUBE", "CUME_DIST", "CURRENT", "CURRENT_CATALOG",
+	"CURRENT_DATE", "CURRENT_DEFAULT_TRANSFORM_GROUP", "CURRENT_PATH", "CURRENT_ROLE", "CURRENT_ROW", "CURRENT_SCHEMA",
+	"CURRENT_TIME", "CURRENT_TIMESTAMP", "CURRENT_TRANSFORM_GROUP_FOR_TYPE", "CURRENT_USER", "CURSOR", "CYCLE",
+	"DATE", "DAY", "DEALLOCATE", "DEC", "DECFLOAT", "DECIMAL", "DECLARE", "DEFAULT", "DEFINE", "DELETE", "DENSE_RANK",
+	"DEREF", "DESCRIBE", "DETERMINISTIC", "DISCONNECT", "DISTINCT", "DOUBLE", "DROP", "DYNAMIC",
+	"EACH", "ELEMENT", "ELSE", "EMPTY", "END", "END_FRAME", "END_PARTITION", "EQUALS", "ESCAPE", "EVERY", "EXCEPT",
+	"EXEC", "EXECUTE", "EXISTS", "EXP", "EXTERNAL", "EXTRACT",
+	"FALSE", "FETCH", "FILTER", "FIRST_VALUE", "FLOAT", "FLOOR", "FOR", "FOREIGN", "FRAME_ROW", "FREE", "FROM", "FULL",
+	"FUNCTION", "FUSION",
+	"GET", "GLOBAL", "GRANT", "GROUP", "GROUPING", "GROUPS",
+	"HAVING", "HOLD", "HOUR",
+	"IDENTITY", "IN", "INDICATOR", "INITIAL", "INNER", "INOUT", "INSENSITIVE", "INSERT", "INT", "INTEGER", "INTERSECT",
+	"INTERSECTION", "INTERVAL", "INTO", "IS",
+	"JOIN", "JSON_ARRAY", "JSON_ARRAYAGG", "JSON_EXISTS", "JSON_OBJECT", "JSON_OBJECTAGG", "JSON_QUERY", "JSON_TABLE",
+	"JSON_TABLE_PRIMITIVE", "JSON_VALUE",
+	"LAG", "LANGUAGE", "LARGE", "LAST_VALUE", "LATERAL", "LEAD", "LEADING", "LEFT", "LIKE", "LIKE_REGEX", "LISTAGG",
+	"LN", "LOCAL", "LOCALTIME", "LOCALTIMESTAMP", "LOG", "LOG10", "LOWER",
+	"MATCH", "MATCH_NUMBER", "MATCH_RECOGNIZE", "MATCHES", "MAX", "MEASURES", "MEMBER", "MERGE", "METHOD", "MIN",
+	"MINUTE", "MOD", "MODIFIES", "MODULE", "MONTH", "MULTISET",
+	"NATIONAL", "NATURAL", "NCHAR", "NCLOB", "NEW", "NO", "NONE", "NORMALIZE", "NOT", "NTH_VALUE", "NTILE", "NULL",
+	"NULLIF", "NUMERIC",
+	"OCCURRENCES_REGEX", "OCTET_LENGTH", "OF", "OFFSET", "OLD", "OMIT", "ON", "ONE", "ONLY", "OPEN", "OR", "ORDER",
+	"OUT", "OUTER", "OVER", "OVERLAPS", "OVERLAY",
+	"PARAMETER", "PARTITION", "PATTERN", "PER", "PERCENT", "PERCENT_RANK", "PERCENTILE_CONT", "PERCENTILE_DISC",
+	"PERIOD", "PORTION", "POSITION", "POSITION_REGEX", "POWER", "PRECEDES", "PRECISION", "PREPARE", "PRIMARY",
+	"PROCEDURE", "PTF",
+	"RANGE", "RANK", "READS", "REAL", "RECURSIVE", "REF", "REFERENCES", "REFERENCING", "REGR_AVGX", "REGR_AVGY",
+	"REGR_COUNT", "REGR_INTERCEPT", "REGR_R2", "REGR_SLOPE", "REGR_SXX", "REGR_SXY", "REGR_SYY", "RELEASE", "RESULT",
+	"RETURN", "RETURNS", "REVOKE", "RIGHT", "ROLLBACK", "ROLLUP", "ROW", "ROW_NUMBER", "ROWS", "RUNNING",
+	"SAVEPOINT", "SCOPE", "SCROLL", "SEARCH", "SECOND", "SEEK", "SELECT", "SENSITIVE", "SESSION_USER", "SET", "SIMILAR",
+	"SIN", "SINH", "SKIP", "SMALLINT", "SOME", "SPECIFIC", "SPECIFICTYPE", "SQL", "SQLEXCEPTION", "SQLSTATE",
+	"SQLWARNING", "SQRT", "START", "STATIC", "STDDEV_POP", "STDDEV_SAMP", "SUBMULTISET", "SUBSET", "SUBSTRING",
+	"SUBSTRING_REGEX", "SUCCEEDS", "SUM", "SYMMETRIC", "SYSTEM", "SYSTEM_TIME", "SYSTEM_USER",
+	"TABLE", "TABLESAMPLE", "TAN", "TANH", "THEN", "TIME", "TIMESTAMP", "TIMEZONE_HOUR", "TIMEZONE_MINUTE", "TO",
+	"TRAILING", "TRANSLATE", "TRANSLATE_REGEX", "TRANSLATION", "TREAT", "TRIGGER", "TRIM", "TRIM_ARRAY", "TRUE",
+	"TRUNCATE",
+	"UESCAPE", "UNION", "UNIQUE", "UNKNOWN", "UNNEST", "UPDATE", "UPPER", "USER", "USING",
+	"VALUE", "VALUE_OF", "VALUES", "VAR_POP", "VAR_SAMP", "VARBINARY", "VARCHAR", "VARYING", "VERSIONING",
+	"WHEN", "WHENEVER", "WHERE", "WIDTH_BUCKET", "WINDOW", "WITH", "WITHIN", "WITHOUT",
+	"YEAR",
+
+	// extensions reserved by most engines
+	"ILIKE", "LIMIT",
+}
+
+// nonReservedKeywords are the non-reserved words of the SQL:2016 standard, which may also be used as names. The single
+// letter ones (A, C, G, K, M, P and T) are left out since they are more often used as table aliases.
+var nonReservedKeywords = []string{
+	"ABSOLUTE", "ACTION", "ADA", "ADD", "ADMIN", "AFTER", "ALWAYS", "ASC", "ASSERTION", "ASSIGNMENT", "ATTRIBUTE",
+	"ATTRIBUTES",
+	"BEFORE", "BERNOULLI", "BREADTH",
+	"CASCADE", "CATALOG", "CATALOG_NAME", "CHAIN", "CHAINING", "CHARACTER_SET_CATALOG", "CHARACTER_SET_NAME",
+	"CHARACTER_SET_SCHEMA", "CHARACTERISTICS", "CHARACTERS", "CLASS_ORIGIN", "COBOL", "COLLATION", "COLLATION_CATALOG",
+	"COLLATION_NAME", "COLLATION_SCHEMA", "COLUMN_NAME", "COMMAND_FUNCTION", "COMMAND_FUNCTION_CODE", "COMMITTED",
+	"CONDITION_NUMBER", "CONDITIONAL", "CONNECTION", "CONNECTION_NAME", "CONSTRAINT_CATALOG", "CONSTRAINT_NAME",
+	"CONSTRAINT_SCHEMA", "CONSTRAINTS", "CONSTRUCTOR", "CONTINUE", "CURSOR_NAME",
+	"DATA", "DATETIME_INTERVAL_CODE", "DATETIME_INTERVAL_PRECISION", "DEFAULTS", "DEFERRABLE", "DEFERRED", "DEFINED",
+	"DEFINER", "DEGREE", "DEPTH", "DERIVED", "DESC", "DESCRIPTOR", "DIAGNOSTICS", "DISPATCH", "DOMAIN",
+	"DYNAMIC_FUNCTION", "DYNAMIC_FUNCTION_CODE",
+	"ENCODING", "ENFORCED", "ERROR", "EXCLUDE", "EXCLUDING", "EXPRESSION",
+	"FINAL", "FINISH", "FIRST", "FLAG", "FOLLOWING", "FORMAT", "FORTRAN", "FOUND", "FULFILL",
+	"GENERAL", "GENERATED", "GO", "GOTO", "GRANTED",
+	"HIERARCHY",
+	"IGNORE", "IMMEDIATE", "IMMEDIATELY", "IMPLEMENTATION", "INCLUDING", "INCREMENT", "INITIALLY", "INPUT", "INSTANCE",
+	"INSTANTIABLE", "INSTEAD", "INVOKER", "ISOLATION",
+	"KEEP", "KEY", "KEY_MEMBER", "KEY_TYPE", "KEYS",
+	"LAST", "LENGTH", "LEVEL", "LOCATOR",
+	"MAP", "MATCHED", "MAXVALUE", "MESSAGE_LENGTH", "MESSAGE_OCTET_LENGTH", "MESSAGE_TEXT", "MINVALUE", "MORE", "MUMPS",
+	"NAME", "NAMES", "NESTED", "NESTING", "NEXT", "NFC", "NFD", "NFKC", "NFKD", "NORMALIZED", "NULL_ORDERING",
+	"NULLABLE", "NULLS", "NUMBER",
+	"OBJECT", "OCCURRENCE", "OCTETS", "OPTION", "OPTIONS", "ORDERING", "ORDINALITY", "OTHERS", "OUTPUT", "OVERFLOW",
+	"OVERRIDING",
+	"PAD", "PARAMETER_MODE", "PARAMETER_NAME", "PARAMETER_ORDINAL_POSITION", "PARAMETER_SPECIFIC_CATALOG",
+	"PARAMETER_SPECIFIC_NAME", "PARAMETER_SPECIFIC_SCHEMA", "PARTIAL", "PASCAL", "PASS", "PASSING", "PAST", "PATH",
+	"PLACING", "PLAN", "PLI", "PRECEDING", "PRESERVE", "PRIOR", "PRIVATE", "PRIVILEGES", "PRUNE", "PUBLIC",
+	"QUOTES",
+	"READ", "RELATIVE", "REPEATABLE", "RESPECT", "RESTART", "RESTRICT", "RETURNED_CARDINALITY", "RETURNED_LENGTH",
+	"RETURNED_OCTET_LENGTH", "RETURNED_SQLSTATE", "RETURNING", "ROLE", "ROUTINE", "ROUTINE_CATALOG", "ROUTINE_NAME",
+	"ROUTINE_SCHEMA", "ROW_COUNT",
+	"SCALAR", "SCALE", "SCHEMA", "SCHEMA_NAME", "SCOPE_CATALOG", "SCOPE_NAME", "SCOPE_SCHEMA", "SECTION", "SECURITY",
+	"SELF", "SEQUENCE", "SERIALIZABLE", "SERVER_NAME", "SESSION", "SETS", "SIMPLE", "SIZE", "SOURCE", "SPACE",
+	"SPECIFIC_NAME", "STATE", "STATEMENT", "STRING", "STRUCTURE", "STYLE", "SUBCLASS_ORIGIN",
+	"TABLE_NAME", "TEMPORARY", "THROUGH", "TIES", "TOP_LEVEL_COUNT", "TRANSACTION", "TRANSACTION_ACTIVE",
+	"TRANSACTIONS_COMMITTED", "TRANSACTIONS_ROLLED_BACK", "TRANSFORM", "TRANSFORMS", "TRIGGER_CATALOG", "TRIGGER_NAME",
+	"TRIGGER_SCHEMA", "TYPE",
+	"UNBOUNDED", "UNCOMMITTED", "UNCONDITIONAL", "UNDER", "UNNAMED", "USAGE", "USER_DEFINED_TYPE_CATALOG",
+	"USER_DEFINED_TYPE_CODE", "USER_DEFINED_TYPE_NAME", "USER_DEFINED_TYPE_SCHEMA", "UTF16", "UTF32", "UTF8",
+	"VIEW",
+	"WORK", "WRAPPER", "WRITE",
+	"ZONE",
+
+	// extensions found in most engines
+	"AUTO_INCREMENT", "EXPLAIN", "INDEX", "REPLACE", "SHOW", "TEMP", "UNSIGNED", "VACUUM",
+}
+
+// keywordAsName reports whether the keyword token, found after prev and at the start of rest, is used as a name: any
+// keyword right after a dot, as in t.order, and a non-reserved keyword followed by a dot, as in name.first. Elsewhere,
+// telling whether a non-reserved keyword is a name is left to the parser, since words such as UNBOUNDED in BETWEEN
+// UNBOUNDED PRECEDING or REPLACE in CREATE OR REPLACE follow the same tokens as names do.
+func (l *Lexer) keywordAsName(token, prev Token, rest string) bool {
+	if prev.Type == TokenPunctuation && prev.Value == "." {
+		return true
+	}
+	if rule, ok := l.keyword(token.Value); !ok || rule.reserved {
+		return false
+	}
+	return len(rest) > len(token.Value) && rest[len(token.Value)] == '.'
+}
+
+// keywordRule is a keyword of a lexer, as added to a LexerBuilder.
+type keywordRule struct {
+	value     string
+	tokenType TokenType
+	reserved  bool
+}
+
+// keyword returns the rule of the keyword s, ignoring case.
+func (l *Lexer) keyword(s string) (keywordRule, bool) {
+	var buf [32]byte
+	rule, ok := l.keywords[string(appendFoldKeyword(buf[:0], s))]
+	return rule, ok
+}
+
+// Keywords returns the reserved and non-reserved keywords of the lexer, sorted. Use IsReservedKeyword to tell them
+// apart.
+func (l *Lexer) Keywords() []string {
+	keywords := make([]string, 0, len(l.keywords))
+	for _, rule := range l.keywords {
+		keywords = append(keywords, rule.value)
+	}
+	slices.Sort(keywords)
+	return keywords
+}
+
+// appendFoldKeyword appends the case folded form of a keyword to dst, the key of Lexer.keywords. Each rune is replaced
+// by the smallest rune it folds to, the way strings.EqualFold compares them, which is the uppercase letter for ASCII:
+// "select", "SELECT" and "ſelect" (with a long s) have the same key.
+func appendFoldKeyword(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			for _, r := range s[i:] {
+				dst = utf8.AppendRune(dst, foldRune(r))
+			}
+			return dst
+		}
+		dst = append(dst, byte(upper(int(c))))
+	}
+	return dst
+}
+
+// foldKeyword returns the case folded form of a keyword, see appendFoldKeyword.
+func foldKeyword(s string) string {
+	return string(appendFoldKeyword(nil, s))
+}
+
+// foldRune returns the smallest rune among those r folds to.
+func foldRune(r rune) rune {
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		folded = min(folded, f)
+	}
+	return folded
+}
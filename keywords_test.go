@@ -0,0 +1,153 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKeywordTables(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, keyword := range append(append([]string{}, reservedKeywords...), nonReservedKeywords...) {
+		assert.False(t, seen[keyword], "duplicate keyword %s", keyword)
+		assert.Equal(t, strings.ToUpper(keyword), keyword, "keyword %s", keyword)
+		seen[keyword] = true
+	}
+
+	l := defaultLexer()
+	for _, word := range []string{"select", "Limit", "DISTINCT", "exists", "case", "having"} {
+		assert.True(t, l.IsKeyword(word), "IsKeyword(%q)", word)
+		assert.True(t, l.IsReservedKeyword(word), "IsReservedKeyword(%q)", word)
+	}
+	for _, word := range []string{"name", "Type", "ASC", "desc", "nulls"} {
+		assert.True(t, l.IsKeyword(word), "IsKeyword(%q)", word)
+		assert.False(t, l.IsReservedKeyword(word), "IsReservedKeyword(%q)", word)
+	}
+	for _, word := range []string{"foo", "t", "a"} {
+		assert.False(t, l.IsKeyword(word), "IsKeyword(%q)", word)
+	}
+}
+
+func TestKeywordsAsNames(t *testing.T) {
+	tests := []struct {
+		query    string
+		keywords []string
+	}{
+		{
+			query:    "SELECT t.order, t.name, name.first FROM t",
+			keywords: []string{"SELECT", "FROM"},
+		},
+		{
+			query:    "SELECT t. /* c */ order FROM t /*+ hint */",
+			keywords: []string{"SELECT", "FROM"},
+		},
+		{
+			query:    "SELECT DISTINCT CASE WHEN x > 1 THEN 'a' ELSE 'b' END FROM foo WHERE NOT EXISTS (SELECT 1) LIMIT 10",
+			keywords: []string{"SELECT", "DISTINCT", "CASE", "WHEN", "THEN", "ELSE", "END", "FROM", "WHERE", "NOT", "EXISTS", "SELECT", "LIMIT"},
+		},
+		{
+			// non-reserved keywords used as names are told apart by the parser
+			query:    "SELECT name, type FROM users ORDER BY name DESC NULLS LAST",
+			keywords: []string{"SELECT", "NAME", "TYPE", "FROM", "ORDER BY", "NAME", "DESC", "NULLS", "LAST"},
+		},
+		{
+			query:    "SELECT count(*) OVER (ORDER BY a), first_value(a) OVER (PARTITION BY b) FROM foo",
+			keywords: []string{"SELECT", "COUNT", "OVER", "ORDER BY", "FIRST_VALUE", "OVER", "PARTITION BY", "FROM"},
+		},
+		{
+			query:    "SELECT sum(a) OVER (ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)",
+			keywords: []string{"SELECT", "SUM", "OVER", "ROWS", "BETWEEN", "UNBOUNDED", "PRECEDING", "AND", "CURRENT", "ROW"},
+		},
+		{
+			query:    "ALTER TABLE foo ADD a INT, ADD b INT",
+			keywords: []string{"ALTER", "TABLE", "ADD", "INT", "ADD", "INT"},
+		},
+		{
+			query:    "CREATE OR REPLACE VIEW v AS SELECT 1",
+			keywords: []string{"CREATE", "OR", "REPLACE", "VIEW", "AS", "SELECT"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+
+			var keywords []string
+			for _, token := range tokens {
+				if token.Type == TokenKeyword {
+					keywords = append(keywords, token.Normalized())
+				}
+			}
+			assert.Equal(t, test.keywords, keywords)
+		})
+	}
+}
+
+func TestKeywordCaseFolding(t *testing.T) {
+	l := DefaultLexer().Clone().AddKeyword("ÉTÉ", TokenKeyword).Build()
+
+	for _, word := range []string{"SELECT", "select", "SeLeCt", "ſelect"} {
+		assert.True(t, l.IsKeyword(word), "IsKeyword(%q)", word)
+	}
+	assert.True(t, l.IsReservedKeyword("été"), "IsReservedKeyword")
+	assert.False(t, l.IsKeyword("selec"), "IsKeyword")
+
+	tokens, err := l.GetTokens("select")
+	require.NoError(t, err, "GetTokens")
+	assert.Equal(t, TokenKeyword, tokens[0].Type)
+}
+
+func TestRemoveKeyword(t *testing.T) {
+	l := DefaultLexer().Clone().RemoveKeyword("limit").RemoveKeyword("Name").Build()
+	assert.False(t, l.IsKeyword("LIMIT"), "IsKeyword")
+	assert.False(t, l.IsKeyword("NAME"), "IsKeyword")
+	assert.True(t, DefaultLexer().IsKeyword("LIMIT"), "IsKeyword of the default lexer")
+
+	tokens, err := l.GetTokens("limit")
+	require.NoError(t, err, "GetTokens")
+	assert.Equal(t, TokenName, tokens[0].Type)
+}
+
+func TestLexerKeywords(t *testing.T) {
+	keywords := DefaultLexer().Keywords()
+	assert.Len(t, keywords, len(reservedKeywords)+len(nonReservedKeywords))
+	assert.True(t, slices.IsSorted(keywords), "sorted")
+	assert.Contains(t, keywords, "SELECT")
+	assert.Contains(t, keywords, "NAME")
+
+	keywords = NewLexerBuilder(ANSI).Clear().AddKeyword("B", TokenKeyword).AddNonReservedKeyword("A", TokenKeyword).Build().Keywords()
+	assert.Equal(t, []string{"A", "B"}, keywords)
+}
+
+func BenchmarkIsKeyword(b *testing.B) {
+	l := DefaultLexer()
+	for _, word := range []string{"SELECT", "select", "customer_id", "été"} {
+		b.Run(word, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				l.IsKeyword(word)
+			}
+		})
+	}
+}
+
+func BenchmarkGetTokensCorpus(b *testing.B) {
+	data, err := os.ReadFile("testdata/corpus.sql")
+	require.NoError(b, err, "ReadFile")
+	query := string(data)
+
+	for _, d := range builtinDialects {
+		l := NewLexer(d)
+		b.Run(d.Name(), func(b *testing.B) {
+			b.SetBytes(int64(len(query)))
+			for i := 0; i < b.N; i++ {
+				if _, err := l.GetTokens(query); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -24,6 +24,11 @@ const (
 	TokenNumberFloat
 	TokenString
 	TokenComment
+	TokenError
+	TokenCommentBlock
+	TokenCommentHint
+	TokenQuotedName
+	TokenParameter
 )
 
 type matchInstruction[T any] struct {
@@ -31,150 +36,146 @@ type matchInstruction[T any] struct {
 	instructionType TokenType
 }
 
-var defaultRegexChecks = []matchInstruction[*regexp.Regexp]{
-	{regexp.MustCompile(`[\r\n]+`), TokenNewline},
-	{regexp.MustCompile(`\s+`), TokenWhitespace},
-	{regexp.MustCompile(`--.*?(\r\n|\r|\n|$)`), TokenComment},
-
-	{regexp.MustCompile(`\*`), TokenWildcard},
-	{regexp.MustCompile(`-?\d+(\.\d+)?E-?\d+`), TokenNumberFloat},
-	{regexp.MustCompile(`[^'"()_A-ZÀ-Ü\s,]-?(\d+(\.\d*)|\.\d+)[^'"()_A-ZÀ-Ü\s,]`), TokenNumberFloat},
-	{regexp.MustCompile(`-?\d+`), TokenNumberInteger},
-	{
-		regexp.MustCompile(`'(''|\\'|[^'])*'`),
-		TokenString,
-	},
-	{
-		regexp.MustCompile("`(\\`|[^`])*`"),
-		TokenString,
-	},
-	{
-		regexp.MustCompile(`((LEFT\s+|RIGHT\s+|FULL\s+)?(INNER\s+|OUTER\s+|STRAIGHT\s+)?|(CROSS\s+|NATURAL\s+)?)?JOIN\b`),
-		TokenKeyword,
-	},
-	{regexp.MustCompile(`ORDER\s+BY\b`), TokenKeyword},
-	{regexp.MustCompile(`GROUP\s+BY\b`), TokenKeyword},
-	{regexp.MustCompile(`UNION\s+ALL\b`), TokenKeyword},
-	{
-		regexp.MustCompile(`[<>=~!]+`),
-		TokenOperator,
-	},
-	{
-		regexp.MustCompile(`\[+/@#%^&|^-]+\b`),
-		TokenOperator,
-	},
-	{
-		regexp.MustCompile(`\w[$#\w]*`),
-		TokenUseAsKeyword,
-	},
-	{
-		regexp.MustCompile(`[;()[\],.]`),
-		TokenPunctuation,
-	},
-	{regexp.MustCompile(`[+/@#%^&|-]+`), TokenOperator},
+// Position describes a location in the lexed input.
+type Position struct {
+	Offset int // byte offset, starting at 0
+	Line   int // line number, starting at 1
+	Column int // column counted in runes, starting at 1
 }
 
-var defaultKeywords = []matchInstruction[string]{
-	{"SELECT", TokenKeyword},
-	{"FROM", TokenKeyword},
-	{"WHERE", TokenKeyword},
-	{"AS", TokenKeyword},
-	{"WITH", TokenKeywordCTE},
-	{"AND", TokenKeyword},
-	{"OR", TokenKeyword},
-	{"NOT", TokenKeyword},
-	{"IS", TokenKeyword},
-	{"NULL", TokenKeyword},
-	{"LIKE", TokenKeyword},
-	{"IN", TokenKeyword},
-	{"ORDER", TokenKeyword},
-	{"BY", TokenKeyword},
-	{"UNION", TokenKeyword},
+var startPosition = Position{Offset: 0, Line: 1, Column: 1}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// advance returns the position right after s, assuming s starts at p. "\r\n", "\r" and "\n" all count as a single
+// line break.
+func (p Position) advance(s string) Position {
+	var prevCR bool
+	for _, r := range s {
+		switch {
+		case r == '\n' && prevCR:
+		case r == '\n' || r == '\r':
+			p.Line++
+			p.Column = 1
+		default:
+			p.Column++
+		}
+		prevCR = r == '\r'
+	}
+	p.Offset += len(s)
+
+	return p
 }
 
 type Token struct {
 	Value string
 	Type  TokenType
-}
+	Start Position // position of the first byte of the token
+	End   Position // position right after the last byte of the token
 
-type Lexer struct {
-	regexChecks []matchInstruction[*regexp.Regexp]
-	keywords    []matchInstruction[string]
+	StringKind StringKind // form of the string literal, for TokenString tokens
 }
 
-func defaultLexer() *Lexer {
-	var l Lexer
-
-	for _, check := range defaultRegexChecks {
-		l.AddRegexp(check.value, check.instructionType)
-	}
-
-	for _, keyword := range defaultKeywords {
-		l.AddKeyword(keyword.value, keyword.instructionType)
+// Identifier returns the name of a TokenName or TokenQuotedName token, without quotes nor escapes, so that "order",
+// `order` and [order] all return order. Other tokens return their value.
+func (t Token) Identifier() string {
+	if t.Type != TokenQuotedName || len(t.Value) < 2 {
+		return t.Value
 	}
 
-	return &l
+	closing := string(rune(closingQuote(t.Value[0])))
+	return strings.ReplaceAll(t.Value[1:len(t.Value)-1], closing+closing, closing)
 }
 
-func findInSlice(needle string, haystack []matchInstruction[string]) *matchInstruction[string] {
-	for _, check := range haystack {
-		if check.value == needle {
-			return &check
-		}
+// Normalized returns the canonical form of a keyword token, in uppercase with a single space between words, so that
+// "order\n  by" returns "ORDER BY". Other tokens return their value.
+func (t Token) Normalized() string {
+	if t.Type != TokenKeyword && t.Type != TokenKeywordCTE {
+		return t.Value
 	}
-	return nil
+	return strings.ToUpper(joinWords(t.Value))
 }
 
-// Clear removes all keywords and regex checks from the lexer, useful to reset the lexer to a clean state
-// while adding your own custom keywords and regex checks, without needing to create a new lexer instance.
-func (l *Lexer) Clear() {
-	l.keywords = []matchInstruction[string]{}
-	l.regexChecks = []matchInstruction[*regexp.Regexp]{}
-}
-
-func (l *Lexer) AddKeyword(keyword string, keywordType TokenType) {
-	if findInSlice(keyword, l.keywords) != nil {
-		return
-	}
-	l.keywords = append(l.keywords, matchInstruction[string]{value: keyword, instructionType: keywordType})
-}
+// Identifier quotes recognised by the default lexer, as in "name" and `name`. Brackets are left to the dialects quoting
+// with them, such as SQLServer, so that a[1] is a subscript.
+const defaultIdentifierQuotes = "\"`"
 
-func (l *Lexer) AddRegexp(re *regexp.Regexp, tokenType TokenType) {
-	l.regexChecks = append(l.regexChecks, matchInstruction[*regexp.Regexp]{value: re, instructionType: tokenType})
+// Lexer splits SQL into tokens following the rules of a dialect. A Lexer never changes once created by NewLexer or
+// LexerBuilder.Build, so it is safe for concurrent use. The zero Lexer has no rules and lexes nothing.
+type Lexer struct {
+	builtinRules     bool
+	comments         CommentStyle
+	identifierQuotes string
+	stringQuotes     string
+	stringKinds      stringKindSet
+	operators        []Operator // dialect operators, longest first
+	regexChecks      []matchInstruction[*regexp.Regexp]
+	keywords         map[string]keywordRule // by foldKeyword
 }
 
-func (l *Lexer) process(accum string) (t Token) {
-	var match *matchInstruction[*regexp.Regexp]
+// process returns the token at the start of accum. Malformed tokens, such as an unterminated comment, are returned
+// as TokenError along with a description of the problem. When atEOF is false and the token could change with more
+// data after accum, process returns more instead.
+func (l *Lexer) process(accum string, atEOF bool) (t Token, problem string, more bool) {
 	var strMatch string
-	for _, check := range l.regexChecks {
-		matchPos := check.value.FindStringIndex(accum)
-		if len(matchPos) == 0 {
-			continue
+	var matchType TokenType
+
+	if l.builtinRules {
+		s := ruleScanner{
+			data:             accum,
+			comments:         l.comments,
+			identifierQuotes: l.identifierQuotes,
+			stringQuotes:     l.stringQuotes,
+			stringKinds:      l.stringKinds,
+			operators:        l.operators,
 		}
-		if matchPos[0] != 0 {
-			// we only care about matches on the beginning of the string
-			continue
+		var size int
+		size, matchType = s.scan()
+		if s.hitEnd && !atEOF {
+			return t, "", true
 		}
+		strMatch = accum[:size]
+		problem = s.problem
+		t.StringKind = s.stringKind
+	}
 
-		strMatch = accum[matchPos[0]:matchPos[1]]
-		if strMatch != "" {
-			match = &check
-			break
+	if strMatch == "" {
+		for _, check := range l.regexChecks {
+			var matchPos []int
+			if atEOF {
+				matchPos = check.value.FindStringIndex(accum)
+			} else {
+				r := endReader{data: accum}
+				matchPos = check.value.FindReaderIndex(&r)
+				if r.hitEnd {
+					return t, "", true
+				}
+			}
+			if len(matchPos) == 0 {
+				continue
+			}
+
+			strMatch = accum[:matchPos[1]]
+			if strMatch != "" {
+				matchType = check.instructionType
+				break
+			}
 		}
 	}
 
-	if match == nil {
+	if strMatch == "" {
 		return
 	}
-	matchType := match.instructionType
 
 	if matchType == TokenUnknown {
 		return
 	}
 
 	if matchType == TokenUseAsKeyword {
-		if l.IsKeyword(strMatch) {
-			matchType = TokenKeyword
+		if rule, ok := l.keyword(strMatch); ok {
+			matchType = rule.tokenType
 		} else {
 			matchType = TokenName
 		}
@@ -183,30 +184,62 @@ func (l *Lexer) process(accum string) (t Token) {
 	t.Value = strMatch
 	t.Type = matchType
 
-	return t
+	return t, problem, false
 }
 
+// IsKeyword reports whether s is a reserved or non-reserved keyword, ignoring case.
 func (l *Lexer) IsKeyword(s string) bool {
-	return findInSlice(strings.ToUpper(s), l.keywords) != nil
+	_, ok := l.keyword(s)
+	return ok
+}
+
+// IsReservedKeyword reports whether s is a reserved keyword, ignoring case.
+func (l *Lexer) IsReservedKeyword(s string) bool {
+	rule, ok := l.keyword(s)
+	return ok && rule.reserved
 }
 
+// GetTokens splits data into tokens. When part of data can't be matched by any rule, the returned error is a
+// *SyntaxError pointing to it.
+//
+// Tokens are lossless: concatenating the Value of every token reproduces data byte for byte, including whitespace,
+// comments and invalid UTF-8, so that SQL can be rewritten in place by replacing some tokens. Each token starts where
+// the previous one ends, as told by its Start and End positions.
 func (l *Lexer) GetTokens(data string) ([]Token, error) {
 	var tokens []Token
-	var pos int
-
-	for pos < len(data) {
-		token := l.process(data[pos:])
-		if token.Value == "" {
-			return nil, fmt.Errorf("could not parse token at position %d", pos)
-		}
 
-		tokens = append(tokens, token)
-		pos += len(token.Value)
+	s := l.newStringScanner(data)
+	for s.Scan() {
+		tokens = append(tokens, s.Token())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
 	}
 
 	return tokens, nil
 }
 
+// GetTokensTolerant splits data into tokens without ever giving up. Input that can't be matched by any rule is
+// emitted as TokenError tokens, and a *SyntaxError is returned for each of them. The tokens are lossless, as for
+// GetTokens, even when data can't be lexed.
+func (l *Lexer) GetTokensTolerant(data string) ([]Token, []*SyntaxError) {
+	var tokens []Token
+
+	s := l.newStringScanner(data)
+	s.tolerant = true
+	for s.Scan() {
+		tokens = append(tokens, s.Token())
+	}
+
+	return tokens, s.Diagnostics()
+}
+
+// GetTokens splits data into tokens using the default lexer, see Lexer.GetTokens.
 func GetTokens(data string) ([]Token, error) {
 	return defaultLexer().GetTokens(data)
 }
+
+// GetTokensTolerant splits data into tokens using the default lexer, see Lexer.GetTokensTolerant.
+func GetTokensTolerant(data string) ([]Token, []*SyntaxError) {
+	return defaultLexer().GetTokensTolerant(data)
+}
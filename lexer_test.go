@@ -30,14 +30,16 @@ func TestProcess(t *testing.T) {
 		{"1,", "1", TokenNumberInteger},
 		{"1E99", "1E99", TokenNumberFloat},
 		{"1.99", "1.99", TokenNumberFloat},
-		{"-1.99", "-1.99", TokenNumberFloat},
+		{"-1.99", "-", TokenOperator},
+		{"1e-3", "1e-3", TokenNumberFloat},
+		{"0xFF", "0xFF", TokenNumberInteger},
 	}
 
 	lexer := defaultLexer()
 
 	for _, test := range tests {
 		t.Run(test.piece, func(t *testing.T) {
-			token := lexer.process(test.piece)
+			token, _, _ := lexer.process(test.piece, true)
 			assert.Equal(t, test.expectedMatch, token.Value, "token.Value")
 			assert.Equalf(t, test.expectedType, token.Type, "token.Type: expected %s is not %s", test.expectedType, token.Type)
 		})
@@ -150,3 +152,114 @@ func TestDefaultLexer(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenPositions(t *testing.T) {
+	tokens, err := GetTokens("SELECT 'ção',\r\n  x\n-- done\nFROM\rfoo")
+	require.NoError(t, err, "GetTokens")
+
+	expected := []struct {
+		value string
+		start Position
+		end   Position
+	}{
+		{"SELECT", Position{0, 1, 1}, Position{6, 1, 7}},
+		{" ", Position{6, 1, 7}, Position{7, 1, 8}},
+		{"'ção'", Position{7, 1, 8}, Position{14, 1, 13}},
+		{",", Position{14, 1, 13}, Position{15, 1, 14}},
+		{"\r\n", Position{15, 1, 14}, Position{17, 2, 1}},
+		{"  ", Position{17, 2, 1}, Position{19, 2, 3}},
+		{"x", Position{19, 2, 3}, Position{20, 2, 4}},
+		{"\n", Position{20, 2, 4}, Position{21, 3, 1}},
+		{"-- done\n", Position{21, 3, 1}, Position{29, 4, 1}},
+		{"FROM", Position{29, 4, 1}, Position{33, 4, 5}},
+		{"\r", Position{33, 4, 5}, Position{34, 5, 1}},
+		{"foo", Position{34, 5, 1}, Position{37, 5, 4}},
+	}
+
+	require.Len(t, tokens, len(expected), "tokens")
+	for i, e := range expected {
+		assert.Equal(t, e.value, tokens[i].Value, "tokens[%d].Value", i)
+		assert.Equal(t, e.start, tokens[i].Start, "tokens[%d].Start", i)
+		assert.Equal(t, e.end, tokens[i].End, "tokens[%d].End", i)
+	}
+}
+
+func TestGetTokensErrorPosition(t *testing.T) {
+	_, err := GetTokens("SELECT *\nFROM foo\nWHERE a = {")
+	require.Error(t, err, "GetTokens")
+
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, Position{Offset: 28, Line: 3, Column: 11}, syntaxErr.Position)
+	assert.Equal(t, '{', syntaxErr.Rune)
+	assert.Equal(t, "3:11: could not parse token starting with '{'", err.Error())
+	assert.Equal(t, "WHERE a = {\n          ^", syntaxErr.Snippet)
+}
+
+func TestGetTokensTolerant(t *testing.T) {
+	const query = "SELECT a }} b,\n{c} FROM foo"
+	tokens, diagnostics := GetTokensTolerant(query)
+
+	var sb strings.Builder
+	var errorTokens []Token
+	for _, token := range tokens {
+		sb.WriteString(token.Value)
+		if token.Type == TokenError {
+			errorTokens = append(errorTokens, token)
+		}
+	}
+	assert.Equal(t, query, sb.String(), "query")
+
+	require.Len(t, errorTokens, 3, "errorTokens")
+	assert.Equal(t, "}}", errorTokens[0].Value)
+	assert.Equal(t, Position{Offset: 9, Line: 1, Column: 10}, errorTokens[0].Start)
+	assert.Equal(t, "{", errorTokens[1].Value)
+	assert.Equal(t, "}", errorTokens[2].Value)
+
+	require.Len(t, diagnostics, 3, "diagnostics")
+	assert.Equal(t, "1:10: could not parse token starting with '}'", diagnostics[0].Error())
+	assert.Equal(t, "2:1: could not parse token starting with '{'", diagnostics[1].Error())
+	assert.Equal(t, "2:3: could not parse token starting with '}'", diagnostics[2].Error())
+
+	_, err := GetTokens(query)
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, diagnostics[0], syntaxErr)
+}
+
+// FuzzGetTokens checks that the tokens of every dialect are contiguous, never empty and reproduce the input byte for
+// byte, whether or not it can be lexed.
+func FuzzGetTokens(f *testing.F) {
+	for _, query := range scannerCorpus {
+		f.Add(query)
+	}
+	for _, query := range []string{"", "\x00", "\xff\xfe", "'", "\"", "`", "[", "/*", "$$", "$a$", "0x", "1e", "\r\n\r"} {
+		f.Add(query)
+	}
+
+	f.Fuzz(func(t *testing.T, query string) {
+		for _, d := range builtinDialects {
+			lexer := NewLexer(d)
+			tokens, diagnostics := lexer.GetTokensTolerant(query)
+
+			var sb strings.Builder
+			end := Position{Line: 1, Column: 1}
+			for i, token := range tokens {
+				require.NotEmpty(t, token.Value, "%s: tokens[%d].Value", d.Name(), i)
+				require.Equal(t, end, token.Start, "%s: tokens[%d].Start", d.Name(), i)
+				require.Equal(t, token.Start.Offset+len(token.Value), token.End.Offset, "%s: tokens[%d].End", d.Name(), i)
+				sb.WriteString(token.Value)
+				end = token.End
+			}
+			require.Equal(t, query, sb.String(), "%s: round trip", d.Name())
+
+			strict, err := lexer.GetTokens(query)
+			if len(diagnostics) == 0 {
+				require.NoError(t, err, "%s: GetTokens", d.Name())
+				require.Equal(t, tokens, strict, "%s: GetTokens", d.Name())
+			} else {
+				require.Equal(t, diagnostics[0], err, "%s: GetTokens", d.Name())
+			}
+		}
+	})
+}
@@ -0,0 +1,217 @@
+package sqlparse
+
+import (
+	"strings"
+)
+
+// StringKind tells apart the different forms of string literals, available in Token.StringKind.
+type StringKind int
+
+const (
+	StringNone         StringKind = iota // the token is not a string
+	StringStandard                       // 'text'
+	StringEscape                         // E'text\n', with backslash escapes (PostgreSQL)
+	StringNational                       // N'text'
+	StringUnicode                        // U&'text'
+	StringHex                            // X'ff'
+	StringBit                            // B'0101'
+	StringDollar                         // $$text$$ or $tag$text$tag$ (PostgreSQL)
+	StringRaw                            // r'text', where backslashes are kept as they are (BigQuery)
+	StringBytes                          // b'text' (BigQuery)
+	StringRawBytes                       // rb'text' or br'text' (BigQuery)
+	StringTripleQuoted                   // '''text''' or """text""", which may span lines (BigQuery)
+)
+
+// defaultStringKinds are the optional string forms recognised by the default lexer
+var defaultStringKinds = []StringKind{
+	StringEscape, StringNational, StringUnicode, StringHex, StringBit, StringDollar,
+}
+
+// stringKindSet is a set of string kinds, one bit per kind
+type stringKindSet uint32
+
+func newStringKindSet(kinds ...StringKind) stringKindSet {
+	var set stringKindSet
+	for _, kind := range kinds {
+		set |= 1 << kind
+	}
+	return set
+}
+
+func (set stringKindSet) has(kind StringKind) bool {
+	return set&(1<<kind) != 0
+}
+
+// StringKinds sets the optional string literal forms recognised by the lexer, replacing the previous ones. Standard
+// strings are always recognised. When both StringBit and StringBytes are set, b'...' is taken as bytes, and
+// StringRawBytes is recognised when both StringRaw and StringBytes are set.
+func (b *LexerBuilder) StringKinds(kinds ...StringKind) *LexerBuilder {
+	b.lexer.stringKinds = newStringKindSet(kinds...)
+	return b
+}
+
+// stringPrefix returns the size and kind of the prefix of the string literal at the start of the data, if any.
+func (s *ruleScanner) stringPrefix() (int, StringKind) {
+	c0, c1 := lower(s.at(0)), lower(s.at(1))
+
+	switch {
+	case (c0 == 'r' && c1 == 'b' || c0 == 'b' && c1 == 'r') && s.stringKinds.has(StringRaw) && s.stringKinds.has(StringBytes):
+		return 2, StringRawBytes
+	case c0 == 'u' && c1 == '&' && s.stringKinds.has(StringUnicode):
+		return 2, StringUnicode
+	case c0 == 'e' && s.stringKinds.has(StringEscape):
+		return 1, StringEscape
+	case c0 == 'n' && s.stringKinds.has(StringNational):
+		return 1, StringNational
+	case c0 == 'x' && s.stringKinds.has(StringHex):
+		return 1, StringHex
+	case c0 == 'b' && s.stringKinds.has(StringBytes):
+		return 1, StringBytes
+	case c0 == 'b' && s.stringKinds.has(StringBit):
+		return 1, StringBit
+	case c0 == 'r' && s.stringKinds.has(StringRaw):
+		return 1, StringRaw
+	}
+
+	return 0, StringStandard
+}
+
+// scanString matches string literals in all the forms enabled in s.stringKinds, setting s.stringKind.
+func (s *ruleScanner) scanString() (int, TokenType) {
+	if n, tokenType := s.scanDollarString(); n > 0 {
+		return n, tokenType
+	}
+
+	i, kind := s.stringPrefix()
+	quote := s.at(i)
+	if quote == eof || strings.IndexByte(s.stringQuotes, byte(quote)) < 0 {
+		if i == 0 {
+			return 0, TokenUnknown
+		}
+		// not a prefix after all, but the start of a name
+		i, kind = 0, StringStandard
+		if quote = s.at(0); quote == eof || strings.IndexByte(s.stringQuotes, byte(quote)) < 0 {
+			return 0, TokenUnknown
+		}
+	}
+
+	var n int
+	switch {
+	case s.stringKinds.has(StringTripleQuoted) && s.at(i+1) == quote && s.at(i+2) == quote:
+		if kind == StringStandard {
+			kind = StringTripleQuoted
+		}
+		n = s.scanEscapedString(i+3, quote, true)
+	case kind == StringEscape || kind == StringRaw || kind == StringBytes || kind == StringRawBytes:
+		n = s.scanEscapedString(i+1, quote, false)
+	default:
+		n = s.scanQuotedString(i, quote)
+	}
+
+	if n == 0 {
+		s.problem = "unterminated string literal"
+		return len(s.data), TokenError
+	}
+
+	s.stringKind = kind
+	return n, TokenString
+}
+
+// scanQuotedString matches a string quoted by quote starting at position i. For single quotes, that is
+//
+//	'(''|\\'|[^'])*'
+//
+// Quotes are only taken as escaped when another quote closes the string later on, which is how the expression
+// backtracks.
+func (s *ruleScanner) scanQuotedString(i int, quote int) int {
+	if !s.hasByteFrom(i+1, byte(quote)) {
+		return 0
+	}
+
+	for i++; ; {
+		switch c := s.at(i); {
+		case (c == quote || c == '\\') && s.at(i+1) == quote && s.hasByteFrom(i+2, byte(quote)):
+			i += 2
+		case c == quote:
+			return i + 1
+		default:
+			i++
+		}
+	}
+}
+
+// scanEscapedString matches the rest of a string starting at position i, where backslashes escape the following
+// character. Triple quoted strings end at three quotes in a row, others at a quote that is not doubled.
+func (s *ruleScanner) scanEscapedString(i int, quote int, triple bool) int {
+	for {
+		switch s.at(i) {
+		case eof:
+			return 0
+		case '\\':
+			i += 2
+		case quote:
+			if triple {
+				if s.at(i+1) == quote && s.at(i+2) == quote {
+					return i + 3
+				}
+				i++
+			} else if s.at(i+1) == quote {
+				i += 2
+			} else {
+				return i + 1
+			}
+		default:
+			i++
+		}
+	}
+}
+
+// scanDollarString matches PostgreSQL dollar quoted strings, $$text$$ or $tag$text$tag$.
+func (s *ruleScanner) scanDollarString() (int, TokenType) {
+	if s.at(0) != '$' || !s.stringKinds.has(StringDollar) {
+		return 0, TokenUnknown
+	}
+
+	i := 1
+	if c := s.at(i); c != '$' {
+		if !isLetter(c) && c != '_' {
+			return 0, TokenUnknown
+		}
+		i = s.skip(i, isWordByte)
+		if s.at(i) != '$' {
+			return 0, TokenUnknown
+		}
+	}
+	i++
+
+	tag := s.data[:i]
+	end := strings.Index(s.data[i:], tag)
+	if end < 0 {
+		s.hitEnd = true
+		s.problem = "unterminated dollar-quoted string"
+		return len(s.data), TokenError
+	}
+
+	s.stringKind = StringDollar
+	return i + end + len(tag), TokenString
+}
+
+func isLetter(c int) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// upper returns the uppercase version of an ASCII letter, or c itself.
+func upper(c int) int {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// lower returns the lowercase version of an ASCII letter, or c itself.
+func lower(c int) int {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
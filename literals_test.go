@@ -0,0 +1,105 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStringLiterals(t *testing.T) {
+	tests := []struct {
+		query string
+		value string
+		kind  StringKind
+	}{
+		{`'it''s' x`, `'it''s'`, StringStandard},
+		{`'a\'b' x`, `'a\'b'`, StringStandard},
+		{`E'a\'b\\' x`, `E'a\'b\\'`, StringEscape},
+		{`e'\\' || 'x'`, `e'\\'`, StringEscape},
+		{`E'it''s' x`, `E'it''s'`, StringEscape},
+		{`N'ação' x`, `N'ação'`, StringNational},
+		{`n'abc' x`, `n'abc'`, StringNational},
+		{`U&'d\0061t' x`, `U&'d\0061t'`, StringUnicode},
+		{`X'ff' x`, `X'ff'`, StringHex},
+		{`B'0101' x`, `B'0101'`, StringBit},
+		{"$$ SELECT 'a'; -- $ \n$$ x", "$$ SELECT 'a'; -- $ \n$$", StringDollar},
+		{"$fn$ BEGIN RETURN $$x$$; END $fn$ x", "$fn$ BEGIN RETURN $$x$$; END $fn$", StringDollar},
+		{"$_1$a$_1$ x", "$_1$a$_1$", StringDollar},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+			require.Greater(t, len(tokens), 1, "tokens")
+
+			assert.Equal(t, test.value, tokens[0].Value, "Value")
+			assert.Equal(t, TokenString, tokens[0].Type, "Type")
+			assert.Equal(t, test.kind, tokens[0].StringKind, "StringKind")
+		})
+	}
+}
+
+func TestStringLiteralsBigQuery(t *testing.T) {
+	l := NewLexer(BigQuery)
+
+	tests := []struct {
+		query string
+		value string
+		kind  StringKind
+	}{
+		{`"abc" x`, `"abc"`, StringStandard},
+		{`'''it's''' x`, `'''it's'''`, StringTripleQuoted},
+		{"\"\"\"multi\n\"line\" end\"\"\" x", "\"\"\"multi\n\"line\" end\"\"\"", StringTripleQuoted},
+		{`r"\d+\"" x`, `r"\d+\""`, StringRaw},
+		{`R'''a\'''b''' x`, `R'''a\'''b'''`, StringRaw},
+		{`b'\x00' x`, `b'\x00'`, StringBytes},
+		{`rb'\x' x`, `rb'\x'`, StringRawBytes},
+		{`BR"x" x`, `BR"x"`, StringRawBytes},
+		{`'' x`, `''`, StringStandard},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := l.GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+			require.Greater(t, len(tokens), 1, "tokens")
+
+			assert.Equal(t, test.value, tokens[0].Value, "Value")
+			assert.Equal(t, TokenString, tokens[0].Type, "Type")
+			assert.Equal(t, test.kind, tokens[0].StringKind, "StringKind")
+		})
+	}
+}
+
+func TestStringPrefixNotEnabled(t *testing.T) {
+	tokens, err := GetTokens(`SELECT r'x', e 'y'`)
+	require.NoError(t, err, "GetTokens")
+
+	var values []string
+	for _, token := range tokens {
+		values = append(values, token.Value)
+	}
+	assert.Equal(t, []string{"SELECT", " ", "r", "'x'", ",", " ", "e", " ", "'y'"}, values)
+	assert.Equal(t, StringNone, tokens[2].StringKind)
+	assert.Equal(t, StringStandard, tokens[3].StringKind)
+}
+
+func TestUnterminatedStrings(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT 'abc", "1:8: unterminated string literal"},
+		{"SELECT 'abc'' ", "1:13: unterminated string literal"},
+		{"SELECT E'abc\\'", "1:8: unterminated string literal"},
+		{"SELECT $fn$ abc $$", "1:8: unterminated dollar-quoted string"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			_, err := GetTokens(test.query)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
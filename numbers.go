@@ -0,0 +1,120 @@
+package sqlparse
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Number is the value of a numeric literal, see Token.Number.
+type Number struct {
+	Token Token
+
+	// Float is the value of the literal as the nearest float64, infinite for floats out of its range
+	Float float64
+	// Int is the value of an integer literal when IsInt is set. Integers overflowing an int64 only have Float.
+	Int   int64
+	IsInt bool
+}
+
+// Number parses a TokenNumberInteger or TokenNumberFloat token, ignoring the digit separators. It returns false for
+// other tokens.
+func (t Token) Number() (Number, bool) {
+	n := Number{Token: t}
+	digits := strings.ReplaceAll(t.Value, "_", "")
+
+	switch t.Type {
+	case TokenNumberInteger:
+		base := 10
+		if len(digits) > 2 && digits[0] == '0' {
+			switch lower(int(digits[1])) {
+			case 'x':
+				base, digits = 16, digits[2:]
+			case 'b':
+				base, digits = 2, digits[2:]
+			}
+		}
+		i, ok := new(big.Int).SetString(digits, base)
+		if !ok {
+			return Number{}, false
+		}
+		n.Float, _ = new(big.Float).SetInt(i).Float64()
+		n.Int, n.IsInt = i.Int64(), i.IsInt64()
+	case TokenNumberFloat:
+		f, err := strconv.ParseFloat(digits, 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return Number{}, false
+		}
+		n.Float = f
+	default:
+		return Number{}, false
+	}
+
+	return n, true
+}
+
+// scanNumber matches the integers and decimals with an optional exponent, as 42, 1_000, .5, 5., 1e10 or 1.5E-3, and
+// the hexadecimal 0xFF and binary 0b101 integers. A leading sign is left to the operator rules, so that a-1 reads as a
+// minus between a and 1.
+func (s *ruleScanner) scanNumber() (int, TokenType) {
+	if s.at(0) == '0' {
+		switch lower(s.at(1)) {
+		case 'x':
+			if j := s.skipDigits(2, isHexDigit); j > 2 {
+				return j, TokenNumberInteger
+			}
+		case 'b':
+			if j := s.skipDigits(2, isBinaryDigit); j > 2 {
+				return j, TokenNumberInteger
+			}
+		}
+	}
+
+	tokenType := TokenNumberInteger
+	i := s.skipDigits(0, isDigit)
+	if s.at(i) == '.' {
+		// 5. and .5 are decimals, a lone dot is punctuation
+		if j := s.skipDigits(i+1, isDigit); j > i+1 || i > 0 {
+			i, tokenType = j, TokenNumberFloat
+		}
+	}
+	if i == 0 {
+		return 0, TokenUnknown
+	}
+
+	if c := s.at(i); c == 'e' || c == 'E' {
+		j := i + 1
+		if c := s.at(j); c == '+' || c == '-' {
+			j++
+		}
+		if k := s.skipDigits(j, isDigit); k > j {
+			i, tokenType = k, TokenNumberFloat
+		}
+	}
+
+	return i, tokenType
+}
+
+// skipDigits returns the position after the digits found at i, which may be separated by single underscores.
+func (s *ruleScanner) skipDigits(i int, fn func(c int) bool) int {
+	start := i
+	for {
+		switch c := s.at(i); {
+		case fn(c):
+			i++
+		case c == '_' && i > start && fn(s.at(i+1)):
+			i++
+		default:
+			return i
+		}
+	}
+}
+
+func isHexDigit(c int) bool {
+	return isDigit(c) || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func isBinaryDigit(c int) bool {
+	return c == '0' || c == '1'
+}
@@ -0,0 +1,130 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestNumberTokens(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []Token
+	}{
+		{
+			query: "a-1",
+			expected: []Token{
+				{Value: "a", Type: TokenName},
+				{Value: "-", Type: TokenOperator},
+				{Value: "1", Type: TokenNumberInteger},
+			},
+		},
+		{
+			query: "-.5+5.",
+			expected: []Token{
+				{Value: "-", Type: TokenOperator},
+				{Value: ".5", Type: TokenNumberFloat},
+				{Value: "+", Type: TokenOperator},
+				{Value: "5.", Type: TokenNumberFloat},
+			},
+		},
+		{
+			query: "1e10,1E-3,2.5e+2",
+			expected: []Token{
+				{Value: "1e10", Type: TokenNumberFloat},
+				{Value: ",", Type: TokenPunctuation},
+				{Value: "1E-3", Type: TokenNumberFloat},
+				{Value: ",", Type: TokenPunctuation},
+				{Value: "2.5e+2", Type: TokenNumberFloat},
+			},
+		},
+		{
+			query: "0xFF,0b101,1_000_000",
+			expected: []Token{
+				{Value: "0xFF", Type: TokenNumberInteger},
+				{Value: ",", Type: TokenPunctuation},
+				{Value: "0b101", Type: TokenNumberInteger},
+				{Value: ",", Type: TokenPunctuation},
+				{Value: "1_000_000", Type: TokenNumberInteger},
+			},
+		},
+		{
+			query: "(1.5)",
+			expected: []Token{
+				{Value: "(", Type: TokenPunctuation},
+				{Value: "1.5", Type: TokenNumberFloat},
+				{Value: ")", Type: TokenPunctuation},
+			},
+		},
+		{
+			query: "1e",
+			expected: []Token{
+				{Value: "1", Type: TokenNumberInteger},
+				{Value: "e", Type: TokenName},
+			},
+		},
+		{
+			query: "1__0",
+			expected: []Token{
+				{Value: "1", Type: TokenNumberInteger},
+				{Value: "__0", Type: TokenName},
+			},
+		},
+		{
+			query: "0x",
+			expected: []Token{
+				{Value: "0", Type: TokenNumberInteger},
+				{Value: "x", Type: TokenName},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+
+			require.Len(t, tokens, len(test.expected), "tokens")
+			for i, e := range test.expected {
+				assert.Equal(t, e.Value, tokens[i].Value, "tokens[%d].Value", i)
+				assert.Equal(t, e.Type, tokens[i].Type, "tokens[%d].Type", i)
+			}
+		})
+	}
+}
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		token Token
+		float float64
+		int   int64
+		isInt bool
+	}{
+		{Token{Value: "42", Type: TokenNumberInteger}, 42, 42, true},
+		{Token{Value: "007", Type: TokenNumberInteger}, 7, 7, true},
+		{Token{Value: "1_000", Type: TokenNumberInteger}, 1000, 1000, true},
+		{Token{Value: "0xFF", Type: TokenNumberInteger}, 255, 255, true},
+		{Token{Value: "0B101", Type: TokenNumberInteger}, 5, 5, true},
+		{Token{Value: "18446744073709551616", Type: TokenNumberInteger}, 18446744073709551616, 0, false},
+		{Token{Value: ".5", Type: TokenNumberFloat}, 0.5, 0, false},
+		{Token{Value: "5.", Type: TokenNumberFloat}, 5, 0, false},
+		{Token{Value: "1.5E-3", Type: TokenNumberFloat}, 0.0015, 0, false},
+		{Token{Value: "1_0.2_5", Type: TokenNumberFloat}, 10.25, 0, false},
+		{Token{Value: "1e999", Type: TokenNumberFloat}, math.Inf(1), 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.token.Value, func(t *testing.T) {
+			n, ok := test.token.Number()
+			require.True(t, ok, "Number")
+			assert.Equal(t, test.token, n.Token, "Token")
+			assert.Equal(t, test.float, n.Float, "Float")
+			assert.Equal(t, test.int, n.Int, "Int")
+			assert.Equal(t, test.isInt, n.IsInt, "IsInt")
+		})
+	}
+
+	_, ok := Token{Value: "a", Type: TokenName}.Number()
+	assert.False(t, ok, "Number of a name")
+}
@@ -0,0 +1,160 @@
+package sqlparse
+
+// Operator is an operator of a dialect, see Dialect.Operators.
+type Operator struct {
+	Symbol     string
+	Precedence int // how tightly the operator binds, one of the Precedence constants
+}
+
+// Operator precedences, from the loosest to the tightest, as in PostgreSQL.
+const (
+	PrecedenceNone           = iota // not an operator
+	PrecedenceOr                    // OR
+	PrecedenceAnd                   // AND
+	PrecedenceNot                   // NOT
+	PrecedenceIs                    // IS NULL, IS DISTINCT FROM
+	PrecedenceComparison            // = <> < > <= >=
+	PrecedencePredicate             // BETWEEN, IN, LIKE
+	PrecedenceOther                 // || and any other operator, such as -> or @>
+	PrecedenceAdditive              // + -
+	PrecedenceMultiplicative        // * / %
+	PrecedenceExponent              // ^
+	PrecedenceCast                  // ::
+)
+
+// standardOperators are the operators of every dialect.
+var standardOperators = []Operator{
+	{"=", PrecedenceComparison},
+	{"<>", PrecedenceComparison},
+	{"!=", PrecedenceComparison},
+	{"<", PrecedenceComparison},
+	{">", PrecedenceComparison},
+	{"<=", PrecedenceComparison},
+	{">=", PrecedenceComparison},
+	{"+", PrecedenceAdditive},
+	{"-", PrecedenceAdditive},
+	{"/", PrecedenceMultiplicative},
+	{"%", PrecedenceMultiplicative},
+	{"||", PrecedenceOther},
+	{"&", PrecedenceOther},
+	{"|", PrecedenceOther},
+	{"^", PrecedenceOther},
+	{"~", PrecedenceOther},
+	{"!", PrecedenceOther},
+	{"#", PrecedenceOther},
+	{"@", PrecedenceOther},
+	{"<<", PrecedenceOther},
+	{">>", PrecedenceOther},
+	{"::", PrecedenceCast},
+}
+
+// jsonOperators are the JSON operators of PostgreSQL, also found in MySQL and SQLite.
+var jsonOperators = []Operator{
+	{"->", PrecedenceOther},
+	{"->>", PrecedenceOther},
+}
+
+// postgresOperators are the operators of PostgreSQL besides the standard and JSON ones.
+var postgresOperators = []Operator{
+	{"^", PrecedenceExponent},
+	{"#>", PrecedenceOther},
+	{"#>>", PrecedenceOther},
+	{"#-", PrecedenceOther},
+	{"@>", PrecedenceOther},
+	{"<@", PrecedenceOther},
+	{"@@", PrecedenceOther},
+	{"@?", PrecedenceOther},
+	{"&&", PrecedenceOther},
+	{"-|-", PrecedenceOther},
+	{"<->", PrecedenceOther},
+	{"^@", PrecedenceOther},
+	{"~*", PrecedenceOther},
+	{"!~", PrecedenceOther},
+	{"!~*", PrecedenceOther},
+	{"~~", PrecedenceOther},
+	{"!~~", PrecedenceOther},
+	{"~~*", PrecedenceOther},
+	{"!~~*", PrecedenceOther},
+	{"=>", PrecedenceNone},
+}
+
+// ansiOperators are the operators of other engines that the ANSI dialect accepts, as it does for quotes and strings.
+var ansiOperators = []Operator{
+	{"#>", PrecedenceOther},
+	{"#>>", PrecedenceOther},
+	{"@>", PrecedenceOther},
+	{"<@", PrecedenceOther},
+	{"&&", PrecedenceOther},
+	{"<=>", PrecedenceComparison},
+	{"~*", PrecedenceOther},
+	{"!~", PrecedenceOther},
+	{"!~*", PrecedenceOther},
+}
+
+// keywordPrecedences are the precedences of the keywords used as operators, by normalized value.
+var keywordPrecedences = map[string]int{
+	"OR":                   PrecedenceOr,
+	"XOR":                  PrecedenceOr,
+	"AND":                  PrecedenceAnd,
+	"NOT":                  PrecedenceNot,
+	"IS":                   PrecedenceIs,
+	"IS NULL":              PrecedenceIs,
+	"IS NOT NULL":          PrecedenceIs,
+	"IS DISTINCT FROM":     PrecedenceIs,
+	"IS NOT DISTINCT FROM": PrecedenceIs,
+	"ISNULL":               PrecedenceIs,
+	"NOTNULL":              PrecedenceIs,
+	"BETWEEN":              PrecedencePredicate,
+	"NOT BETWEEN":          PrecedencePredicate,
+	"IN":                   PrecedencePredicate,
+	"NOT IN":               PrecedencePredicate,
+	"LIKE":                 PrecedencePredicate,
+	"NOT LIKE":             PrecedencePredicate,
+	"ILIKE":                PrecedencePredicate,
+	"NOT ILIKE":            PrecedencePredicate,
+	"SIMILAR TO":           PrecedencePredicate,
+	"NOT SIMILAR TO":       PrecedencePredicate,
+	"REGEXP":               PrecedencePredicate,
+	"RLIKE":                PrecedencePredicate,
+	"GLOB":                 PrecedencePredicate,
+	"DIV":                  PrecedenceMultiplicative,
+	"MOD":                  PrecedenceMultiplicative,
+}
+
+// Precedence returns how tightly the operator token binds, one of the Precedence constants. Keywords used as operators,
+// such as AND or IS DISTINCT FROM, have a precedence too, and so has the wildcard as a multiplication. Other tokens
+// return PrecedenceNone.
+func (l *Lexer) Precedence(t Token) int {
+	switch t.Type {
+	case TokenOperator:
+		for _, op := range l.operators {
+			if op.Symbol == t.Value {
+				return op.Precedence
+			}
+		}
+	case TokenWildcard:
+		return PrecedenceMultiplicative
+	case TokenKeyword:
+		return keywordPrecedences[t.Normalized()]
+	}
+	return PrecedenceNone
+}
+
+// Precedence returns how tightly the operator token binds using the default lexer, see Lexer.Precedence.
+func Precedence(t Token) int {
+	return defaultLexer().Precedence(t)
+}
+
+// scanOperator matches the longest of s.operators found at the start of the data. Operators are made of symbols, so
+// names and numbers are skipped without going through the table.
+func (s *ruleScanner) scanOperator() int {
+	if isWordByte(s.at(0)) {
+		return 0
+	}
+	for _, op := range s.operators {
+		if j := s.word(0, op.Symbol); j >= 0 {
+			return j
+		}
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOperators(t *testing.T) {
+	tests := []struct {
+		dialect   Dialect
+		query     string
+		operators []string
+	}{
+		{ANSI, "a::int", []string{"::"}},
+		{ANSI, "a->b->>c", []string{"->", "->>"}},
+		{ANSI, "a #>>b #>c", []string{"#>>", "#>"}},
+		{ANSI, "a@>b<@c", []string{"@>", "<@"}},
+		{ANSI, "a||b&&c", []string{"||", "&&"}},
+		{ANSI, "a<=>b<=c<>d>=e", []string{"<=>", "<=", "<>", ">="}},
+		{ANSI, "a!~*b~*c!~d", []string{"!~*", "~*", "!~"}},
+		{ANSI, "a=-b", []string{"=", "-"}},
+		{ANSI, "a<<b>>c", []string{"<<", ">>"}},
+		{ANSI, "a+@b", []string{"+"}},
+		{PostgreSQL, "a?|b?&c?d", []string{"?|", "?&", "?"}},
+		{PostgreSQL, "a!~~*b-|-c<->d", []string{"!~~*", "-|-", "<->"}},
+		{MySQL, "a:=b<=>c", []string{":=", "<=>"}},
+		{SQLite, "a==b", []string{"=="}},
+		{SQLServer, "a*=b", []string{"*="}},
+		{Snowflake, "f(a=>b)", []string{"=>"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect.Name()+"/"+test.query, func(t *testing.T) {
+			tokens, err := NewLexer(test.dialect).GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+
+			var operators []string
+			for _, token := range tokens {
+				if token.Type == TokenOperator {
+					operators = append(operators, token.Value)
+				}
+			}
+			assert.Equal(t, test.operators, operators)
+		})
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	tests := []struct {
+		dialect    Dialect
+		token      Token
+		precedence int
+	}{
+		{ANSI, Token{Value: "OR", Type: TokenKeyword}, PrecedenceOr},
+		{ANSI, Token{Value: "and", Type: TokenKeyword}, PrecedenceAnd},
+		{ANSI, Token{Value: "NOT", Type: TokenKeyword}, PrecedenceNot},
+		{ANSI, Token{Value: "is  distinct\nfrom", Type: TokenKeyword}, PrecedenceIs},
+		{ANSI, Token{Value: "=", Type: TokenOperator}, PrecedenceComparison},
+		{ANSI, Token{Value: "NOT IN", Type: TokenKeyword}, PrecedencePredicate},
+		{ANSI, Token{Value: "||", Type: TokenOperator}, PrecedenceOther},
+		{ANSI, Token{Value: "->>", Type: TokenOperator}, PrecedenceOther},
+		{ANSI, Token{Value: "-", Type: TokenOperator}, PrecedenceAdditive},
+		{ANSI, Token{Value: "*", Type: TokenWildcard}, PrecedenceMultiplicative},
+		{ANSI, Token{Value: "::", Type: TokenOperator}, PrecedenceCast},
+		{ANSI, Token{Value: "SELECT", Type: TokenKeyword}, PrecedenceNone},
+		{ANSI, Token{Value: "a", Type: TokenName}, PrecedenceNone},
+		{PostgreSQL, Token{Value: "^", Type: TokenOperator}, PrecedenceExponent},
+		{MySQL, Token{Value: "||", Type: TokenOperator}, PrecedenceOr},
+		{MySQL, Token{Value: "&&", Type: TokenOperator}, PrecedenceAnd},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect.Name()+"/"+test.token.Value, func(t *testing.T) {
+			assert.Equal(t, test.precedence, NewLexer(test.dialect).Precedence(test.token))
+		})
+	}
+
+	assert.Equal(t, PrecedenceComparison, Precedence(Token{Value: "<>", Type: TokenOperator}), "Precedence")
+}
@@ -0,0 +1,136 @@
+package sqlparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Parameter describes a bind parameter or placeholder, see Token.Parameter.
+type Parameter struct {
+	Token Token
+
+	// Prefix is what introduces the parameter: "?", "$", ":", "@", "@@" for variables, or "%" for %(name)s
+	Prefix string
+	// Name is the name of a named parameter without its prefix nor suffix, as id for :id, @id or %(id)s
+	Name string
+	// Ordinal is the 1-based number of a numbered parameter such as $1, ?1 or :1. Positional ? parameters only have an
+	// ordinal when returned by Parameters, telling their position among the positional parameters of the statement.
+	Ordinal int
+}
+
+// Parameter parses a TokenParameter token. It returns false for other tokens.
+func (t Token) Parameter() (Parameter, bool) {
+	if t.Type != TokenParameter || t.Value == "" {
+		return Parameter{}, false
+	}
+
+	p := Parameter{Token: t}
+	switch {
+	case strings.HasPrefix(t.Value, "@@"):
+		p.Prefix, p.Name = "@@", t.Value[2:]
+	case strings.HasPrefix(t.Value, "%("):
+		p.Prefix, p.Name = "%", strings.TrimSuffix(t.Value[2:], ")s")
+	default:
+		p.Prefix = t.Value[:1]
+		if n, err := strconv.Atoi(t.Value[1:]); err == nil {
+			p.Ordinal = n
+		} else {
+			p.Name = t.Value[1:]
+		}
+	}
+
+	return p, true
+}
+
+// Parameters returns the parameters found in tokens, in order. Positional ? parameters are numbered from 1 in the
+// order they appear, so that the number of arguments needed by a statement can be checked before running it.
+func Parameters(tokens []Token) []Parameter {
+	var params []Parameter
+	var positional int
+	for _, token := range tokens {
+		p, ok := token.Parameter()
+		if !ok {
+			continue
+		}
+		if p.Prefix == "?" && p.Ordinal == 0 && p.Name == "" {
+			positional++
+			p.Ordinal = positional
+		}
+		params = append(params, p)
+	}
+
+	return params
+}
+
+// ArgCount returns the number of arguments needed to run a statement with the given parameters, as returned by
+// Parameters. A parameter used twice, as in $1 = $1 or :id = :id, needs a single argument, and $3 alone needs three.
+// Numbered parameters are counted for each prefix, so $2 and ?1 need three arguments. @@ server variables need none.
+func ArgCount(params []Parameter) int {
+	ordinals := make(map[string]int)
+	names := make(map[string]bool)
+	for _, p := range params {
+		switch {
+		case p.Prefix == "@@":
+		case p.Name != "":
+			names[p.Prefix+p.Name] = true
+		default:
+			ordinals[p.Prefix] = max(ordinals[p.Prefix], p.Ordinal)
+		}
+	}
+
+	count := len(names)
+	for _, n := range ordinals {
+		count += n
+	}
+	return count
+}
+
+// scanParameter matches a bind parameter starting at position i and returns the position after it, or i when there is
+// none. It matches `\?\d*`, `\$\d+`, `:\w+`, `@@?[A-Za-z_][$#\w]*` and `%\(\w+\)s`. A ? is always taken as a
+// parameter, even though PostgreSQL also uses it as a jsonb operator.
+func (s *ruleScanner) scanParameter(i int) int {
+	switch s.at(i) {
+	case '?':
+		return s.skip(i+1, isDigit)
+	case '$':
+		if j := s.skip(i+1, isDigit); j > i+1 {
+			return j
+		}
+	case ':':
+		if j := s.skip(i+1, isWordByte); j > i+1 {
+			return j
+		}
+	case '@':
+		j := i + 1
+		if s.at(j) == '@' {
+			j++
+		}
+		if c := s.at(j); isWordByte(c) && !isDigit(c) {
+			return s.skip(j+1, isNameByte)
+		}
+	case '%':
+		if s.at(i+1) != '(' {
+			break
+		}
+		if j := s.skip(i+2, isWordByte); j > i+2 && s.at(j) == ')' && s.at(j+1) == 's' {
+			return j + 2
+		}
+	}
+
+	return i
+}
+
+// isSliceColon reports whether the :name parameter token, found after prev, is rather a colon followed by a name or a
+// number, as in the array slice a[lo:hi]: a parameter never follows an operand.
+func isSliceColon(token, prev Token) bool {
+	if !strings.HasPrefix(token.Value, ":") {
+		return false
+	}
+	switch prev.Type {
+	case TokenName, TokenQuotedName, TokenNumberInteger, TokenNumberFloat, TokenString:
+		return true
+	case TokenPunctuation:
+		return prev.Value == ")" || prev.Value == "]"
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParameters(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []Parameter
+	}{
+		{
+			query: "SELECT * FROM foo WHERE a = ? AND b IN (?, ?)",
+			expected: []Parameter{
+				{Prefix: "?", Ordinal: 1},
+				{Prefix: "?", Ordinal: 2},
+				{Prefix: "?", Ordinal: 3},
+			},
+		},
+		{
+			query:    "SELECT ?2, ?1",
+			expected: []Parameter{{Prefix: "?", Ordinal: 2}, {Prefix: "?", Ordinal: 1}},
+		},
+		{
+			query:    "UPDATE foo SET a = $2 WHERE id = $1",
+			expected: []Parameter{{Prefix: "$", Ordinal: 2}, {Prefix: "$", Ordinal: 1}},
+		},
+		{
+			query:    "SELECT :name, :1, a::int FROM foo",
+			expected: []Parameter{{Prefix: ":", Name: "name"}, {Prefix: ":", Ordinal: 1}},
+		},
+		{
+			query:    "SELECT a[1:2], f(x):y, a[:n] FROM foo WHERE b = :b",
+			expected: []Parameter{{Prefix: ":", Name: "n"}, {Prefix: ":", Name: "b"}},
+		},
+		{
+			query:    "SET @total = @a+@b_1, @@session_var",
+			expected: []Parameter{{Prefix: "@", Name: "total"}, {Prefix: "@", Name: "a"}, {Prefix: "@", Name: "b_1"}, {Prefix: "@@", Name: "session_var"}},
+		},
+		{
+			query:    "SELECT a % 2 FROM foo WHERE id = %(id)s",
+			expected: []Parameter{{Prefix: "%", Name: "id"}},
+		},
+		{
+			query:    "SELECT $$?$$, '?', \"?\" -- ?",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+
+			params := Parameters(tokens)
+			require.Len(t, params, len(test.expected), "params")
+			for i, p := range params {
+				assert.Equal(t, TokenParameter, p.Token.Type, "params[%d].Token.Type", i)
+				p.Token = Token{}
+				assert.Equal(t, test.expected[i], p, "params[%d]", i)
+			}
+		})
+	}
+}
+
+func TestArgCount(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{query: "SELECT 1", expected: 0},
+		{query: "SELECT ?, ?", expected: 2},
+		{query: "SELECT $1, $1", expected: 1},
+		{query: "SELECT $2 + $1", expected: 2},
+		{query: "SELECT $3", expected: 3},
+		{query: "SELECT :id, :name FROM t WHERE id = :id", expected: 2},
+		{query: "SELECT @a, @@version", expected: 1},
+		{query: "SELECT $2, ?1", expected: 3},
+		{query: "SELECT ?, $1, :1, :id", expected: 4},
+		{query: "SELECT ?, ?2", expected: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+			assert.Equal(t, test.expected, ArgCount(Parameters(tokens)))
+		})
+	}
+}
+
+func TestParameterTokens(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []Token
+	}{
+		{
+			query: "a::int",
+			expected: []Token{
+				{Value: "a", Type: TokenName},
+				{Value: "::", Type: TokenOperator},
+				{Value: "int", Type: TokenKeyword},
+			},
+		},
+		{
+			query: "a@>b",
+			expected: []Token{
+				{Value: "a", Type: TokenName},
+				{Value: "@>", Type: TokenOperator},
+				{Value: "b", Type: TokenName},
+			},
+		},
+		{
+			query: "a-@b",
+			expected: []Token{
+				{Value: "a", Type: TokenName},
+				{Value: "-", Type: TokenOperator},
+				{Value: "@b", Type: TokenParameter},
+			},
+		},
+		{
+			query: "a%(b)",
+			expected: []Token{
+				{Value: "a", Type: TokenName},
+				{Value: "%", Type: TokenOperator},
+				{Value: "(", Type: TokenPunctuation},
+				{Value: "b", Type: TokenName},
+				{Value: ")", Type: TokenPunctuation},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+
+			require.Len(t, tokens, len(test.expected), "tokens")
+			for i, token := range tokens {
+				assert.Equal(t, test.expected[i].Value, token.Value, "tokens[%d].Value", i)
+				assert.Equal(t, test.expected[i].Type, token.Type, "tokens[%d].Type", i)
+			}
+		})
+	}
+
+	_, ok := Token{Value: "a", Type: TokenName}.Parameter()
+	assert.False(t, ok, "Parameter on a name")
+}
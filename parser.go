@@ -0,0 +1,288 @@
+package sqlparse
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Parse parses a single statement into its syntax tree. The statement may end with a semicolon. SELECT, INSERT,
+// UPDATE, DELETE and MERGE statements are supported, as well as CREATE, ALTER and DROP for tables, indexes and views.
+//
+// The returned error is a *SyntaxError, pointing at the first token that doesn't fit the grammar.
+func (l *Lexer) Parse(data string) (Stmt, error) {
+	tokens, err := l.GetTokens(data)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{lexer: l, data: data}
+	for _, token := range tokens {
+		if !slices.Contains(triviaTypes, token.Type) && token.Type != TokenCommentHint {
+			p.tokens = append(p.tokens, token)
+		}
+	}
+
+	stmt, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+	p.acceptPunct(";")
+	if !p.atEOF() {
+		return nil, p.errorf("expected end of statement, found %s", p.describe())
+	}
+	return stmt, nil
+}
+
+// Parse parses a single statement using the default lexer, see Lexer.Parse.
+func Parse(data string) (Stmt, error) {
+	return defaultLexer().Parse(data)
+}
+
+// parser is a recursive descent parser reading the tokens of a statement, without trivia.
+type parser struct {
+	lexer  *Lexer
+	data   string
+	tokens []Token
+	pos    int
+}
+
+func (p *parser) parseStatement() (Stmt, error) {
+	start, pos := p.peek(), p.pos
+
+	// a WITH clause may precede INSERT, UPDATE and DELETE too
+	with, recursive, err := p.parseWith()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.isKeyword("INSERT", "INSERT INTO"):
+		s, err := p.parseInsert(start)
+		if err != nil {
+			return nil, err
+		}
+		s.With, s.Recursive = with, recursive
+		return s, nil
+	case p.isKeyword("UPDATE"):
+		s, err := p.parseUpdate(start)
+		if err != nil {
+			return nil, err
+		}
+		s.With, s.Recursive = with, recursive
+		return s, nil
+	case p.isKeyword("DELETE"):
+		s, err := p.parseDelete(start)
+		if err != nil {
+			return nil, err
+		}
+		s.With, s.Recursive = with, recursive
+		return s, nil
+	case with != nil || p.isKeyword("SELECT") || p.isPunct("("):
+		// the query parses its own WITH clause
+		p.pos = pos
+		return p.parseQuery()
+	case p.isKeyword("MERGE"):
+		return p.parseMerge()
+	case p.isKeyword("CREATE"):
+		return p.parseCreate()
+	case p.isKeyword("ALTER"):
+		return p.parseAlterTable()
+	case p.isKeyword("DROP"):
+		return p.parseDrop()
+	case p.atEOF():
+		return nil, p.errorf("expected a statement, found end of input")
+	}
+	return nil, p.errorf("unsupported statement %s", p.describe())
+}
+
+// peek returns the current token, or an empty token at the end of the statement.
+func (p *parser) peek() Token {
+	return p.peekAt(0)
+}
+
+// peekAt returns the token n tokens after the current one, or an empty token past the end of the statement.
+func (p *parser) peekAt(n int) Token {
+	if p.pos+n < len(p.tokens) {
+		return p.tokens[p.pos+n]
+	}
+	return Token{}
+}
+
+// next returns the current token and moves past it.
+func (p *parser) next() Token {
+	token := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return token
+}
+
+func (p *parser) atEOF() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// isKeyword reports whether the current token is one of the given keywords, in their Normalized form.
+func (p *parser) isKeyword(keywords ...string) bool {
+	return isKeywordToken(p.peek(), keywords...)
+}
+
+// acceptKeyword moves past the current token if it is one of the given keywords.
+func (p *parser) acceptKeyword(keywords ...string) bool {
+	if p.isKeyword(keywords...) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expectKeyword moves past the current token, which must be the given keyword.
+func (p *parser) expectKeyword(keyword string) (Token, error) {
+	if !p.isKeyword(keyword) {
+		return Token{}, p.errorf("expected %s, found %s", keyword, p.describe())
+	}
+	return p.next(), nil
+}
+
+// isWord reports whether the current token is one of the given words, either as a keyword or as a name. Non-reserved
+// keywords are lexed as names where a name could be expected, as TIES in FETCH FIRST 1 ROW WITH TIES.
+func (p *parser) isWord(words ...string) bool {
+	return isWordToken(p.peek(), words...)
+}
+
+// acceptWord moves past the current token if it is one of the given words, see isWord.
+func (p *parser) acceptWord(words ...string) bool {
+	if p.isWord(words...) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expectWord moves past the current token, which must be the given word, see isWord.
+func (p *parser) expectWord(word string) error {
+	if !p.acceptWord(word) {
+		return p.errorf("expected %s, found %s", word, p.describe())
+	}
+	return nil
+}
+
+func (p *parser) isPunct(value string) bool {
+	token := p.peek()
+	return token.Type == TokenPunctuation && token.Value == value
+}
+
+func (p *parser) acceptPunct(value string) bool {
+	if p.isPunct(value) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectPunct(value string) error {
+	if !p.acceptPunct(value) {
+		return p.errorf("expected %q, found %s", value, p.describe())
+	}
+	return nil
+}
+
+// span returns the span from start to the end of the last token read.
+func (p *parser) span(start Token) Span {
+	return Span{Start: start.Start, End: p.tokens[p.pos-1].End}
+}
+
+// describe returns the current token as shown in error messages.
+func (p *parser) describe() string {
+	if p.atEOF() {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", p.peek().Value)
+}
+
+// errorf returns a syntax error at the current token.
+func (p *parser) errorf(format string, args ...any) *SyntaxError {
+	var pos Position
+	if p.atEOF() {
+		pos = startPosition.advance(p.data)
+	} else {
+		pos = p.peek().Start
+	}
+	return newSyntaxError(p.data, pos.Offset, pos, fmt.Sprintf(format, args...))
+}
+
+// parseIdent parses a name, a quoted name, or a non-reserved keyword used as a name.
+func (p *parser) parseIdent() (*Ident, error) {
+	token := p.peek()
+	if !p.isIdent(token) {
+		return nil, p.errorf("expected a name, found %s", p.describe())
+	}
+	p.pos++
+	return &Ident{Span: Span{Start: token.Start, End: token.End}, Name: token.Identifier(), Quoted: token.Type == TokenQuotedName}, nil
+}
+
+// isIdent reports whether the token can be used as a name.
+func (p *parser) isIdent(token Token) bool {
+	switch token.Type {
+	case TokenName, TokenQuotedName:
+		return true
+	case TokenKeyword:
+		return !strings.ContainsAny(token.Value, " \t\r\n") && !p.lexer.IsReservedKeyword(token.Value)
+	}
+	return false
+}
+
+// parseIdentList parses a parenthesised list of names, as in USING (a, b).
+func (p *parser) parseIdentList() ([]*Ident, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var idents []*Ident
+	for {
+		ident, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		idents = append(idents, ident)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+	return idents, p.expectPunct(")")
+}
+
+// parseObjectName parses a name with its optional qualifiers, such as catalog.schema.table. Any keyword is accepted
+// after a dot.
+func (p *parser) parseObjectName() (*ObjectName, error) {
+	start := p.peek()
+	ident, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	name := ObjectName{Parts: []*Ident{ident}}
+	for p.isPunct(".") {
+		p.pos++
+		ident, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		name.Parts = append(name.Parts, ident)
+	}
+	name.Span = p.span(start)
+	return &name, nil
+}
+
+// isWordToken reports whether the token is one of the given words, either as a keyword or as a name.
+func isWordToken(t Token, words ...string) bool {
+	if t.Type == TokenName {
+		return slices.Contains(words, strings.ToUpper(t.Value))
+	}
+	return isKeywordToken(t, words...)
+}
+
+func isKeywordToken(t Token, keywords ...string) bool {
+	if t.Type != TokenKeyword && t.Type != TokenKeywordCTE {
+		return false
+	}
+	return slices.Contains(keywords, t.Normalized())
+}
@@ -0,0 +1,94 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const query = "-- list\nSELECT a /* first */ FROM t;\n"
+	q := parseQuery(t, query)
+	assert.Equal(t, Span{Start: Position{Offset: 8, Line: 2, Column: 1}, End: Position{Offset: 35, Line: 2, Column: 28}}, q.Span)
+	assert.Equal(t, "SELECT a /* first */ FROM t", q.Text(query), "Text")
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query    string
+		msg      string
+		position Position
+	}{
+		{query: "", msg: "expected a statement, found end of input", position: Position{Offset: 0, Line: 1, Column: 1}},
+		{query: "GRANT SELECT ON t TO u", msg: `unsupported statement "GRANT"`, position: Position{Offset: 0, Line: 1, Column: 1}},
+		{query: "SELECT FROM t", msg: `expected an expression, found "FROM"`, position: Position{Offset: 7, Line: 1, Column: 8}},
+		{query: "SELECT a FROM", msg: "expected a name, found end of input", position: Position{Offset: 13, Line: 1, Column: 14}},
+		{query: "SELECT a; SELECT b", msg: `expected end of statement, found "SELECT"`, position: Position{Offset: 10, Line: 1, Column: 11}},
+		{query: "SELECT a FROM (SELECT b", msg: `expected ")", found end of input`, position: Position{Offset: 23, Line: 1, Column: 24}},
+		{query: "SELECT a\nFETCH FIRST 1 ROWS", msg: "expected ONLY, found end of input", position: Position{Offset: 27, Line: 2, Column: 19}},
+		{query: "SELECT 'a", msg: "unterminated string literal", position: Position{Offset: 7, Line: 1, Column: 8}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			_, err := Parse(test.query)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, test.msg, syntaxErr.Msg, "Msg")
+			assert.Equal(t, test.position, syntaxErr.Position, "Position")
+		})
+	}
+}
+
+func TestParseNonReservedKeywordsAsNames(t *testing.T) {
+	queries := []string{
+		"SELECT name, type FROM users ORDER BY name DESC NULLS LAST",
+		"UPDATE foo SET data = 1, level = level + 1 WHERE path = :path",
+		"CREATE TABLE foo (key INT, size TEXT, PRIMARY KEY (key))",
+		"SELECT rank() OVER (PARTITION BY name ORDER BY level ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM t",
+		"ALTER TABLE foo ADD a INT, ADD b INT",
+		"CREATE OR REPLACE VIEW v AS SELECT name FROM t",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			_, err := Parse(query)
+			assert.NoError(t, err)
+		})
+	}
+
+	q := parseQuery(t, "SELECT name, type FROM users")
+	columns := q.Body.(*Select).Columns
+	require.IsType(t, &NameExpr{}, columns[0].Expr)
+	assert.Equal(t, "name", columns[0].Expr.(*NameExpr).Name.String(), "Name")
+	assert.Equal(t, "type", columns[1].Expr.(*NameExpr).Name.String(), "Name")
+	assert.Nil(t, columns[0].Alias, "Alias")
+}
+
+// FuzzParse checks that Parse never panics and that the span of a parsed statement lies within the input.
+func FuzzParse(f *testing.F) {
+	for _, query := range []string{
+		"SELECT a, b c FROM t JOIN u ON t.id = u.id WHERE a > 1",
+		"WITH x AS (SELECT 1) SELECT * FROM x UNION (SELECT 2) ORDER BY 1 LIMIT 1, 2",
+		"SELECT a FROM (t CROSS JOIN LATERAL (SELECT 1) s) FETCH FIRST ROW ONLY",
+		"SELECT CASE WHEN a BETWEEN 1 AND 2 THEN CAST(b AS int) END, count(*) FILTER (WHERE c IN (1)) OVER w",
+		"WITH x AS (SELECT 1) INSERT INTO t (a) VALUES (1), (DEFAULT) ON CONFLICT (a) DO NOTHING RETURNING a",
+		"MERGE INTO t USING u ON t.a = u.a WHEN MATCHED THEN UPDATE SET b = u.b WHEN NOT MATCHED THEN INSERT VALUES (u.a)",
+		"CREATE TABLE t (a int NOT NULL DEFAULT 0 PRIMARY KEY, b text REFERENCES u (id) ON DELETE CASCADE, UNIQUE (a, b))",
+		"ALTER TABLE t ADD COLUMN c int, ALTER COLUMN b SET NOT NULL, RENAME COLUMN a TO d",
+	} {
+		f.Add(query)
+	}
+
+	f.Fuzz(func(t *testing.T, query string) {
+		stmt, err := Parse(query)
+		if err != nil {
+			return
+		}
+		span := stmt.Pos()
+		require.LessOrEqual(t, 0, span.Start.Offset, "Start")
+		require.LessOrEqual(t, span.Start.Offset, span.End.Offset, "End")
+		require.LessOrEqual(t, span.End.Offset, len(query), "End")
+	})
+}
@@ -0,0 +1,374 @@
+package sqlparse
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const eof = -1
+
+// ruleScanner matches the default token rules by hand in a single pass. Rules that replaced a regular expression keep
+// it in their comment.
+type ruleScanner struct {
+	data             string
+	comments         CommentStyle
+	identifierQuotes string
+	stringQuotes     string
+	stringKinds      stringKindSet
+	operators        []Operator // longest first
+
+	// hitEnd is set when a rule looked at the end of the data, meaning more data could change the result
+	hitEnd bool
+	// problem describes why the token was returned as TokenError
+	problem string
+	// stringKind is the kind of the string literal matched
+	stringKind StringKind
+}
+
+// at returns the byte at position i, or eof when i is past the end of the data.
+func (s *ruleScanner) at(i int) int {
+	if i >= len(s.data) {
+		s.hitEnd = true
+		return eof
+	}
+	return int(s.data[i])
+}
+
+// runeAt returns the rune at position i and its size, or a zero size when i is past the end of the data.
+func (s *ruleScanner) runeAt(i int) (rune, int) {
+	if i >= len(s.data) {
+		s.hitEnd = true
+		return eof, 0
+	}
+	if !utf8.FullRuneInString(s.data[i:]) {
+		s.hitEnd = true
+	}
+	return utf8.DecodeRuneInString(s.data[i:])
+}
+
+// hasByteFrom reports whether c appears anywhere from position i onwards.
+func (s *ruleScanner) hasByteFrom(i int, c byte) bool {
+	if i < len(s.data) && strings.IndexByte(s.data[i:], c) >= 0 {
+		return true
+	}
+	s.hitEnd = true
+	return false
+}
+
+func (s *ruleScanner) skip(i int, fn func(c int) bool) int {
+	for fn(s.at(i)) {
+		i++
+	}
+	return i
+}
+
+// word returns the position after word if it appears at position i ignoring case, or -1. word must be in uppercase.
+func (s *ruleScanner) word(i int, word string) int {
+	for j := 0; j < len(word); j++ {
+		if upper(s.at(i+j)) != int(word[j]) {
+			return -1
+		}
+	}
+	return i + len(word)
+}
+
+// wordThenSpace returns the position after the first of words found at i and the whitespace that must follow it, or
+// -1.
+func (s *ruleScanner) wordThenSpace(i int, words ...string) int {
+	for _, w := range words {
+		if j := s.word(i, w); j >= 0 && s.spaceAt(j) > 0 {
+			return s.skipSpace(j)
+		}
+	}
+	return -1
+}
+
+// wordBoundary returns the position after word if it appears at position i followed by a non-word character, or -1.
+func (s *ruleScanner) wordBoundary(i int, word string) int {
+	if j := s.word(i, word); j >= 0 && !isWordByte(s.at(j)) {
+		return j
+	}
+	return -1
+}
+
+// scan returns the length and type of the token at the start of the data, or a zero length when no rule matches.
+// Malformed tokens are returned as TokenError, with the problem described in s.problem.
+func (s *ruleScanner) scan() (int, TokenType) {
+	c := s.at(0)
+
+	switch {
+	case c == eof:
+		return 0, TokenUnknown
+	case c == '\r' && s.at(1) == '\n':
+		// a single line break per token, so that blank lines can be counted
+		return 2, TokenNewline
+	case c == '\r' || c == '\n':
+		return 1, TokenNewline
+	}
+
+	if n := s.scanSpace(); n > 0 {
+		return n, TokenWhitespace
+	}
+
+	if n := s.scanLineComment(); n > 0 {
+		return n, TokenComment
+	}
+	if n, tokenType := s.scanBlockComment(); n > 0 {
+		return n, tokenType
+	}
+	if n := s.scanOperator(); n > 0 {
+		// a longer parameter wins, so that %(name)s or @name aren't read as an operator
+		if p := s.scanParameter(0); p > n {
+			return p, TokenParameter
+		}
+		return n, TokenOperator
+	}
+	if c == '*' {
+		return 1, TokenWildcard
+	}
+	if n := s.scanParameter(0); n > 0 {
+		return n, TokenParameter
+	}
+	if n, tokenType := s.scanNumber(); n > 0 {
+		return n, tokenType
+	}
+	if n, tokenType := s.scanString(); n > 0 {
+		return n, tokenType
+	}
+	if n, tokenType := s.scanQuotedName(); n > 0 {
+		return n, tokenType
+	}
+	if n := s.scanJoin(); n > 0 {
+		return n, TokenKeyword
+	}
+	if n := s.scanCompoundKeyword(); n > 0 {
+		return n, TokenKeyword
+	}
+	if isWordByte(c) {
+		// `\w[$#\w]*`
+		return s.skip(1, isNameByte), TokenUseAsKeyword
+	}
+	if strings.IndexByte(";()[],.:", byte(c)) >= 0 {
+		// `[;()[\],.:]`
+		return 1, TokenPunctuation
+	}
+
+	return 0, TokenUnknown
+}
+
+// scanLineComment matches `--.*?(\r\n|\r|\n|$)`, and the same starting with # or // when s.comments allows it.
+func (s *ruleScanner) scanLineComment() int {
+	start := 2
+	switch c0, c1 := s.at(0), s.at(1); {
+	case c0 == '-' && c1 == '-':
+	case c0 == '/' && c1 == '/' && s.comments&CommentDoubleSlash != 0:
+	case c0 == '#' && s.comments&CommentHash != 0:
+		start = 1
+	default:
+		return 0
+	}
+
+	end := strings.IndexAny(s.data[start:], "\r\n")
+	if end < 0 {
+		s.hitEnd = true
+		return len(s.data)
+	}
+	end += start
+	if s.at(end) == '\r' && s.at(end+1) == '\n' {
+		return end + 2
+	}
+	return end + 1
+}
+
+// scanBlockComment matches "/* ... */" comments, which may nest when s.comments has CommentNested. Comments starting with
+// "/*+" are optimizer hints and those starting with "/*!" are MySQL executable comments, both returned as
+// TokenCommentHint since removing them changes the query.
+func (s *ruleScanner) scanBlockComment() (int, TokenType) {
+	if s.at(0) != '/' || s.at(1) != '*' {
+		return 0, TokenUnknown
+	}
+
+	tokenType := TokenCommentBlock
+	if c := s.at(2); c == '+' || c == '!' {
+		tokenType = TokenCommentHint
+	}
+
+	depth := 1
+	for i := 2; ; i++ {
+		switch c := s.at(i); {
+		case c == eof:
+			s.problem = "unterminated block comment"
+			return len(s.data), TokenError
+		case c == '*' && s.at(i+1) == '/':
+			i++
+			depth--
+			if depth == 0 {
+				return i + 1, tokenType
+			}
+		case c == '/' && s.at(i+1) == '*' && s.comments&CommentNested != 0:
+			i++
+			depth++
+		}
+	}
+}
+
+// scanQuotedName matches identifiers quoted by any of s.identifierQuotes: "name", `name` or [name]. The closing quote
+// is escaped by doubling it.
+func (s *ruleScanner) scanQuotedName() (int, TokenType) {
+	c := s.at(0)
+	if c == eof || strings.IndexByte(s.identifierQuotes, byte(c)) < 0 {
+		return 0, TokenUnknown
+	}
+
+	closing := closingQuote(byte(c))
+	for i := 1; ; i++ {
+		switch s.at(i) {
+		case eof:
+			s.problem = "unterminated quoted identifier"
+			return len(s.data), TokenError
+		case closing:
+			if s.at(i+1) != closing {
+				return i + 1, TokenQuotedName
+			}
+			i++
+		}
+	}
+}
+
+// scanJoin matches `((LEFT\s+|RIGHT\s+|FULL\s+)?(INNER\s+|OUTER\s+|STRAIGHT\s+)?|(CROSS\s+|NATURAL\s+)?)?JOIN\b`, ignoring
+// case, where `\s` is any space of isSpace.
+func (s *ruleScanner) scanJoin() int {
+	i := 0
+	if j := s.wordThenSpace(i, "LEFT", "RIGHT", "FULL"); j >= 0 {
+		i = j
+		if j := s.wordThenSpace(i, "INNER", "OUTER", "STRAIGHT"); j >= 0 {
+			i = j
+		}
+	} else if j := s.wordThenSpace(i, "INNER", "OUTER", "STRAIGHT", "CROSS", "NATURAL"); j >= 0 {
+		i = j
+	}
+
+	if j := s.wordBoundary(i, "JOIN"); j >= 0 {
+		return j
+	}
+	return 0
+}
+
+// compoundKeywords are the keywords made of several words besides the JOIN family. They are matched ignoring case, with
+// any whitespace between the words.
+var compoundKeywords = [][]string{
+	{"ORDER", "BY"},
+	{"GROUP", "BY"},
+	{"PARTITION", "BY"},
+	{"INSERT", "INTO"},
+	{"IS", "NULL"},
+	{"IS", "NOT", "NULL"},
+	{"IS", "DISTINCT", "FROM"},
+	{"IS", "NOT", "DISTINCT", "FROM"},
+	{"NOT", "IN"},
+	{"NOT", "LIKE"},
+	{"NOT", "ILIKE"},
+	{"NOT", "BETWEEN"},
+	{"NOT", "SIMILAR", "TO"},
+	{"SIMILAR", "TO"},
+	{"UNION", "ALL"},
+	{"UNION", "DISTINCT"},
+	{"INTERSECT", "ALL"},
+	{"INTERSECT", "DISTINCT"},
+	{"EXCEPT", "ALL"},
+	{"EXCEPT", "DISTINCT"},
+	{"TIMESTAMP", "WITH", "TIME", "ZONE"},
+	{"TIMESTAMP", "WITHOUT", "TIME", "ZONE"},
+	{"TIME", "WITH", "TIME", "ZONE"},
+	{"TIME", "WITHOUT", "TIME", "ZONE"},
+	{"DOUBLE", "PRECISION"},
+	{"CHARACTER", "VARYING"},
+	{"CHAR", "VARYING"},
+}
+
+// scanCompoundKeyword matches the words of one of compoundKeywords separated by spaces and followed by `\b`.
+func (s *ruleScanner) scanCompoundKeyword() int {
+next:
+	for _, words := range compoundKeywords {
+		i := 0
+		for _, w := range words[:len(words)-1] {
+			if i = s.wordThenSpace(i, w); i < 0 {
+				continue next
+			}
+		}
+		if j := s.wordBoundary(i, words[len(words)-1]); j >= 0 {
+			return j
+		}
+	}
+	return 0
+}
+
+// closingQuote returns the character closing a quoted identifier opened by c.
+func closingQuote(c byte) int {
+	if c == '[' {
+		return ']'
+	}
+	return int(c)
+}
+
+// scanSpace matches a run of spaces up to the next line break, including tabs and the Unicode spaces pasted from
+// documents, such as the non-breaking space. The Unicode line and paragraph separators are taken as spaces too, as
+// positions only count "\r" and "\n" as line breaks.
+func (s *ruleScanner) scanSpace() int {
+	i := 0
+	for c := s.at(i); c != '\r' && c != '\n'; c = s.at(i) {
+		n := s.spaceAt(i)
+		if n == 0 {
+			break
+		}
+		i += n
+	}
+	return i
+}
+
+// spaceAt returns the size of the space at position i, line breaks included, or 0.
+func (s *ruleScanner) spaceAt(i int) int {
+	if c := s.at(i); c < utf8.RuneSelf {
+		if c >= 0 && isSpace(rune(c)) {
+			return 1
+		}
+		return 0
+	}
+	if r, size := s.runeAt(i); isSpace(r) {
+		return size
+	}
+	return 0
+}
+
+// skipSpace returns the position after the spaces at position i, line breaks included.
+func (s *ruleScanner) skipSpace(i int) int {
+	for n := s.spaceAt(i); n > 0; n = s.spaceAt(i) {
+		i += n
+	}
+	return i
+}
+
+// isSpace reports whether r is whitespace, as `\s` and the Unicode spaces, plus the byte order mark left at the start of
+// files by some editors.
+func isSpace(r rune) bool {
+	return unicode.IsSpace(r) || r == '\uFEFF'
+}
+
+// joinWords returns the words of a compound keyword separated by single spaces, as in "ORDER BY".
+func joinWords(s string) string {
+	return strings.Join(strings.FieldsFunc(s, isSpace), " ")
+}
+
+func isDigit(c int) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isWordByte matches `\w`.
+func isWordByte(c int) bool {
+	return isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isNameByte(c int) bool {
+	return isWordByte(c) || c == '$' || c == '#'
+}
@@ -0,0 +1,421 @@
+package sqlparse
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// regexpRules are regular expressions matching the tokens of the default lexer, used as the reference implementation
+// of the scanner. They can't express block comments nor tagged dollar-quoted strings, so inputs holding them are
+// skipped by assertScannerMatchesRegexp.
+var regexpRules = []matchInstruction[*regexp.Regexp]{
+	{regexp.MustCompile(`\r\n|\r|\n`), TokenNewline},
+	{regexp.MustCompile(`[ \t\f\v` + regexpUnicodeSpaces + `]+`), TokenWhitespace},
+	{regexp.MustCompile(`--[^\r\n]*(\r\n|\r|\n)?`), TokenComment},
+	{regexp.MustCompile(`\?\d*|\$\d+|:\w+|@@?[A-Za-z_][$#\w]*|%\(\w+\)s`), TokenParameter},
+	{regexp.MustCompile(regexpOperators()), TokenOperator},
+	{regexp.MustCompile(`\*`), TokenWildcard},
+	{regexp.MustCompile(`0[xX][0-9a-fA-F]+(_[0-9a-fA-F]+)*|0[bB][01]+(_[01]+)*`), TokenNumberInteger},
+	{regexp.MustCompile(`(\d+(_\d+)*\.(\d+(_\d+)*)?|\.\d+(_\d+)*)([eE][+-]?\d+(_\d+)*)?|\d+(_\d+)*[eE][+-]?\d+(_\d+)*`), TokenNumberFloat},
+	{regexp.MustCompile(`\d+(_\d+)*`), TokenNumberInteger},
+	{regexp.MustCompile(`\$\$(?s:.*?)\$\$`), TokenString},
+	{regexp.MustCompile(`\$\$(?s:.*)`), TokenError},
+	// a string is unterminated when its quotes all pair up until the end
+	{regexp.MustCompile(`[eE]'(\\(?s:.)|''|[^'\\])*\\?$`), TokenError},
+	{regexp.MustCompile(`[eE]'(\\(?s:.)|''|[^'\\])*'`), TokenString},
+	{regexp.MustCompile(`([nNxXbB]|[uU]&)?'(''|\\'|[^'])*'`), TokenString},
+	{regexp.MustCompile(`([nNxXbB]|[uU]&)?'(?s:.*)`), TokenError},
+	{regexp.MustCompile(`"([^"]|"")*$|` + "`([^`]|``)*$"), TokenError},
+	{regexp.MustCompile(`"([^"]|"")*"|` + "`([^`]|``)*`"), TokenQuotedName},
+	{regexp.MustCompile(regexpWords(`((LEFT\s+|RIGHT\s+|FULL\s+)(INNER\s+|OUTER\s+|STRAIGHT\s+)?|(INNER\s+|OUTER\s+|STRAIGHT\s+|CROSS\s+|NATURAL\s+))?JOIN\b`)), TokenKeyword},
+	{regexp.MustCompile(regexpCompoundKeywords()), TokenKeyword},
+	{regexp.MustCompile(`\w[$#\w]*`), TokenUseAsKeyword},
+	{regexp.MustCompile(`[;()[\],.:]`), TokenPunctuation},
+}
+
+// regexpUnicodeSpaces are the spaces of isSpace beyond ASCII, which `\s` doesn't match.
+const regexpUnicodeSpaces = `\x{85}\x{a0}\x{1680}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}\x{feff}`
+
+// regexpOperators returns an expression matching the operators of the default lexer, longest first.
+func regexpOperators() string {
+	var symbols []string
+	for _, op := range defaultLexer().operators {
+		symbols = append(symbols, regexp.QuoteMeta(op.Symbol))
+	}
+	return strings.Join(symbols, "|")
+}
+
+// regexpCompoundKeywords returns an expression matching the compound keywords, as `ORDER\s+BY\b`.
+func regexpCompoundKeywords() string {
+	var keywords []string
+	for _, words := range compoundKeywords {
+		keywords = append(keywords, strings.Join(words, `\s+`)+`\b`)
+	}
+	return regexpWords(strings.Join(keywords, "|"))
+}
+
+// regexpWords makes the uppercase letters of expr match ignoring case, and `\s` match the Unicode spaces too. (?i) can't
+// be used, as it also matches non-ASCII letters, such as the Kelvin sign for K.
+func regexpWords(expr string) string {
+	var b strings.Builder
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == '\\' && expr[i+1] == 's':
+			b.WriteString(`[\s\v` + regexpUnicodeSpaces + `]`)
+			i++
+		case c == '\\':
+			// keep escapes such as \b as they are
+			b.WriteString(expr[i : i+2])
+			i++
+		case c >= 'A' && c <= 'Z':
+			b.WriteString("[" + string(c) + string(c+'a'-'A') + "]")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// regexpLexer is the default lexer with its built-in rules replaced by regexpRules.
+var regexpLexer = sync.OnceValue(func() *Lexer {
+	b := defaultLexer().Clone()
+	b.lexer.builtinRules = false
+	for _, rule := range regexpRules {
+		b.AddRegexp(rule.value, rule.instructionType)
+	}
+	return b.Build()
+})
+
+var scannerCorpus = []string{
+	"SELECT * FROM foo",
+	"SELECT foo, baz FROM bar WHERE foo = 99 AND baz = 'hello world'",
+	"SELECT distance FROM bar WHERE distance >= 314.15E-2 OR distance < -1E5 OR d = 1.5E",
+	"SELECT x1.25 , =1.5 x, 1.2.3, (1.5), .5, 5., -.5 , a-1.5 b, 1.x, .55, x.5), À1.5, é1.5é",
+	"SELECT 'it''s', 'a\\'b', 'abc'', 'x\\'",
+	"SELECT 'unterminated",
+	"SELECT `a` FROM `b`",
+	"SELECT ` FROM x",
+	`SELECT "a""b", [c]]d], "unterminated`,
+	"a LEFT JOIN b RIGHT OUTER JOIN c FULL\tINNER\nJOIN d CROSS JOIN e NATURAL JOIN f LEFT CROSS JOIN g JOINx STRAIGHT JOIN h",
+	"ORDER BY x ORDER  BYx GROUP\nBY y UNION ALL UNION ALLx order by z",
+	"ORDER\u00a0BY x GROUP\vBY y UNION\ufeffALL z LEFT\u3000\r\nJOIN w CROSS\u2028JOIN v ORDER\xa0BY u",
+	"SELECT a -]]b, a -] b, a -]",
+	"SELECT 0xFF, 0b101, 0x, 1_000, 1__0, 1e10, 1E-3, 2.5e+, 5., .5, a-1, 1.2.3",
+	"SELECT a<>b, a!=b, a~b, a||b, a#b, a@b, a^b, a&b, a%b, a/b, a+b, a-b",
+	"SELECT a->>'b', a#>>'{c}', a@>b, a<@b, a<=>b, a!~*b, a&&b, a=-1, a<>-.5, a::int, a IS DISTINCT FROM b",
+	"SELECT *\n-- comment\r\n-- another\rFROM bar -- end",
+	"SELECT \n * FROM foo\r\n\r\n\n  \t\f x",
+	"\ufeffSELECT\u00a0a,\u2003b\u3000FROM\u2028c \r\r\n\n\v\u0085 d\xc2",
+	"SELECT a$b, a#b, _x, 9abc, 123",
+	"SELECT \xe2 1.5\xe2, '\xe2'",
+	"SELECT ?, ?1, $1, :a, :1, a::b, @a, @@b, a+@c, a@>d, %(e)s, %(f), a % b, @1, : x",
+	"SELECT /* block */ a /*+ hint */ FROM /* unterminated",
+	"SELECT a /* multi\nline */ FROM b /**/ c /*/ d */",
+	"SELECT E'a\\'b', N'x', U&'y', X'ff', B'01', $$ a ' b $$, $fn$ $$ $fn$, $x",
+}
+
+func TestScannerMatchesRegexp(t *testing.T) {
+	for _, query := range scannerCorpus {
+		t.Run(query, func(t *testing.T) {
+			assertScannerMatchesRegexp(t, query)
+		})
+	}
+}
+
+func FuzzScannerMatchesRegexp(f *testing.F) {
+	for _, query := range scannerCorpus {
+		f.Add(query)
+	}
+
+	f.Fuzz(func(t *testing.T, query string) {
+		assertScannerMatchesRegexp(t, query)
+	})
+}
+
+// unmatchedByRegexp finds the tokens regexpRules can't express: block comments and tagged dollar-quoted strings
+var unmatchedByRegexp = regexp.MustCompile(`/\*|\$[A-Za-z_]\w*\$`)
+
+func assertScannerMatchesRegexp(t *testing.T, query string) {
+	if unmatchedByRegexp.MatchString(query) {
+		t.Skip("block comment or tagged dollar-quoted string")
+	}
+
+	expected, expectedDiagnostics := regexpLexer().GetTokensTolerant(query)
+	tokens, diagnostics := defaultLexer().GetTokensTolerant(query)
+
+	// the regular expressions tell neither the kind of strings nor the problem with the input
+	for i := range tokens {
+		tokens[i].StringKind = StringNone
+	}
+	for _, diagnostics := range [][]*SyntaxError{expectedDiagnostics, diagnostics} {
+		for _, diagnostic := range diagnostics {
+			diagnostic.Msg = ""
+		}
+	}
+
+	require.Equal(t, expected, tokens, "tokens")
+	require.Equal(t, expectedDiagnostics, diagnostics, "diagnostics")
+}
+
+func TestBlockComments(t *testing.T) {
+	tests := []struct {
+		query          string
+		nestedComments bool
+		expected       []Token
+	}{
+		{
+			query: "/* a */ b",
+			expected: []Token{
+				{Value: "/* a */", Type: TokenCommentBlock},
+				{Value: " ", Type: TokenWhitespace},
+				{Value: "b", Type: TokenName},
+			},
+		},
+		{
+			query:    "/* multi\n * line\n */",
+			expected: []Token{{Value: "/* multi\n * line\n */", Type: TokenCommentBlock}},
+		},
+		{
+			query:    "/**/*",
+			expected: []Token{{Value: "/**/", Type: TokenCommentBlock}, {Value: "*", Type: TokenWildcard}},
+		},
+		{
+			query: "/* a /* b */ c */",
+			expected: []Token{
+				{Value: "/* a /* b */", Type: TokenCommentBlock},
+				{Value: " ", Type: TokenWhitespace},
+				{Value: "c", Type: TokenName},
+				{Value: " ", Type: TokenWhitespace},
+				{Value: "*", Type: TokenWildcard},
+				{Value: "/", Type: TokenOperator},
+			},
+		},
+		{
+			query:          "/* a /* b */ c */",
+			nestedComments: true,
+			expected:       []Token{{Value: "/* a /* b */ c */", Type: TokenCommentBlock}},
+		},
+		{
+			query:    "/*+ INDEX(t idx) */",
+			expected: []Token{{Value: "/*+ INDEX(t idx) */", Type: TokenCommentHint}},
+		},
+		{
+			query:    "/*!40101 SET NAMES utf8 */",
+			expected: []Token{{Value: "/*!40101 SET NAMES utf8 */", Type: TokenCommentHint}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			l := DefaultLexer().Clone().NestedComments(test.nestedComments).Build()
+			tokens, err := l.GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+
+			require.Len(t, tokens, len(test.expected), "tokens")
+			for i, token := range tokens {
+				assert.Equal(t, test.expected[i].Value, token.Value, "tokens[%d].Value", i)
+				assert.Equal(t, test.expected[i].Type, token.Type, "tokens[%d].Type", i)
+			}
+		})
+	}
+}
+
+func TestUnterminatedBlockComment(t *testing.T) {
+	l := DefaultLexer().Clone().NestedComments(true).Build()
+
+	_, err := l.GetTokens("SELECT 1 /* a /* b */")
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, "1:10: unterminated block comment", syntaxErr.Error())
+
+	tokens, diagnostics := l.GetTokensTolerant("SELECT 1 /* a /* b */")
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, Token{
+		Value: "/* a /* b */",
+		Type:  TokenError,
+		Start: Position{Offset: 9, Line: 1, Column: 10},
+		End:   Position{Offset: 21, Line: 1, Column: 22},
+	}, tokens[len(tokens)-1])
+}
+
+func TestWhitespace(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []Token
+	}{
+		{
+			query: "a\r\n\r\n\n\rb",
+			expected: []Token{
+				{Value: "a", Type: TokenName, Start: Position{0, 1, 1}, End: Position{1, 1, 2}},
+				{Value: "\r\n", Type: TokenNewline, Start: Position{1, 1, 2}, End: Position{3, 2, 1}},
+				{Value: "\r\n", Type: TokenNewline, Start: Position{3, 2, 1}, End: Position{5, 3, 1}},
+				{Value: "\n", Type: TokenNewline, Start: Position{5, 3, 1}, End: Position{6, 4, 1}},
+				{Value: "\r", Type: TokenNewline, Start: Position{6, 4, 1}, End: Position{7, 5, 1}},
+				{Value: "b", Type: TokenName, Start: Position{7, 5, 1}, End: Position{8, 5, 2}},
+			},
+		},
+		{
+			query: "a \t\nb",
+			expected: []Token{
+				{Value: "a", Type: TokenName, Start: Position{0, 1, 1}, End: Position{1, 1, 2}},
+				{Value: " \t", Type: TokenWhitespace, Start: Position{1, 1, 2}, End: Position{3, 1, 4}},
+				{Value: "\n", Type: TokenNewline, Start: Position{3, 1, 4}, End: Position{4, 2, 1}},
+				{Value: "b", Type: TokenName, Start: Position{4, 2, 1}, End: Position{5, 2, 2}},
+			},
+		},
+		{
+			query: "\ufeffa\u00a0\u2003\u3000b",
+			expected: []Token{
+				{Value: "\ufeff", Type: TokenWhitespace, Start: Position{0, 1, 1}, End: Position{3, 1, 2}},
+				{Value: "a", Type: TokenName, Start: Position{3, 1, 2}, End: Position{4, 1, 3}},
+				{Value: "\u00a0\u2003\u3000", Type: TokenWhitespace, Start: Position{4, 1, 3}, End: Position{12, 1, 6}},
+				{Value: "b", Type: TokenName, Start: Position{12, 1, 6}, End: Position{13, 1, 7}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+			assert.Equal(t, test.expected, tokens)
+		})
+	}
+}
+
+func TestQuotedNames(t *testing.T) {
+	tests := []struct {
+		dialect    Dialect
+		query      string
+		value      string
+		identifier string
+	}{
+		{ANSI, `"order" x`, `"order"`, "order"},
+		{ANSI, `"say ""hi""" x`, `"say ""hi"""`, `say "hi"`},
+		{ANSI, `"" x`, `""`, ""},
+		{ANSI, "`order` x", "`order`", "order"},
+		{ANSI, "`a``b` x", "`a``b`", "a`b"},
+		{SQLServer, "[order] x", "[order]", "order"},
+		{SQLServer, "[a]]b] x", "[a]]b]", "a]b"},
+		{SQLServer, "[a\nb] x", "[a\nb]", "a\nb"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := NewLexer(test.dialect).GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+			require.Len(t, tokens, 3, "tokens")
+
+			assert.Equal(t, test.value, tokens[0].Value, "Value")
+			assert.Equal(t, TokenQuotedName, tokens[0].Type, "Type")
+			assert.Equal(t, test.identifier, tokens[0].Identifier(), "Identifier")
+		})
+	}
+
+	// brackets are subscripts in the default lexer, as in a[1]
+	tokens, err := GetTokens("[order]")
+	require.NoError(t, err, "GetTokens")
+	assert.Equal(t, Token{Value: "[", Type: TokenPunctuation, Start: startPosition, End: startPosition.advance("[")}, tokens[0])
+
+	_, err = GetTokens(`SELECT "unterminated`)
+	assert.EqualError(t, err, "1:8: unterminated quoted identifier")
+}
+
+func TestCompoundKeywords(t *testing.T) {
+	tests := []struct {
+		query      string
+		value      string
+		normalized string
+	}{
+		{"ORDER BY x", "ORDER BY", "ORDER BY"},
+		{"order by x", "order by", "ORDER BY"},
+		{"Group\n\tBy x", "Group\n\tBy", "GROUP BY"},
+		{"partition by x", "partition by", "PARTITION BY"},
+		{"insert  into x", "insert  into", "INSERT INTO"},
+		{"is null", "is null", "IS NULL"},
+		{"IS NOT NULL", "IS NOT NULL", "IS NOT NULL"},
+		{"not in (1)", "not in", "NOT IN"},
+		{"left join x", "left join", "LEFT JOIN"},
+		{"full outer join x", "full outer join", "FULL OUTER JOIN"},
+		{"Natural Join x", "Natural Join", "NATURAL JOIN"},
+		{"union all x", "union all", "UNION ALL"},
+		{"union distinct x", "union distinct", "UNION DISTINCT"},
+		{"intersect all x", "intersect all", "INTERSECT ALL"},
+		{"except DISTINCT x", "except DISTINCT", "EXCEPT DISTINCT"},
+		{"is not distinct from x", "is not distinct from", "IS NOT DISTINCT FROM"},
+		{"IS DISTINCT FROM b", "IS DISTINCT FROM", "IS DISTINCT FROM"},
+		{"not like 'a%'", "not like", "NOT LIKE"},
+		{"not similar to 'a'", "not similar to", "NOT SIMILAR TO"},
+		{"is distinct x", "is", "IS"},
+		{"not inner", "not", "NOT"},
+		{"ORDER\u00a0BY x", "ORDER\u00a0BY", "ORDER BY"},
+		{"group\v\u2003by x", "group\v\u2003by", "GROUP BY"},
+		{"UNION\ufeffALL x", "UNION\ufeffALL", "UNION ALL"},
+		{"left\u3000join x", "left\u3000join", "LEFT JOIN"},
+		{"order byx", "order", "ORDER"},
+		{"union", "union", "UNION"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			tokens, err := GetTokens(test.query)
+			require.NoError(t, err, "GetTokens")
+
+			assert.Equal(t, test.value, tokens[0].Value, "Value")
+			assert.Equal(t, TokenKeyword, tokens[0].Type, "Type")
+			assert.Equal(t, test.normalized, tokens[0].Normalized(), "Normalized")
+		})
+	}
+
+	assert.Equal(t, "order", Token{Value: "order", Type: TokenName}.Normalized(), "Normalized name")
+}
+
+func TestAddRegexpFallback(t *testing.T) {
+	l := DefaultLexer().Clone().AddRegexp(regexp.MustCompile(`\{\w+\}`), TokenName).Build()
+
+	tokens, err := l.GetTokens("SELECT {col} FROM foo WHERE a = {val}")
+	require.NoError(t, err, "GetTokens")
+
+	var names []string
+	for _, token := range tokens {
+		if token.Type == TokenName {
+			names = append(names, token.Value)
+		}
+	}
+	assert.Equal(t, []string{"{col}", "foo", "a", "{val}"}, names)
+}
+
+func BenchmarkGetTokens(b *testing.B) {
+	const query = "SELECT a.id, b.name, 'it''s' AS s, 3.1415 FROM a LEFT JOIN b ON a.id = b.a_id -- join\nWHERE a.x >= 10 ORDER BY a.id;\n"
+
+	for _, size := range []int{1, 10, 100, 1000} {
+		data := strings.Repeat(query, size)
+		b.Run(fmt.Sprintf("default/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, err := GetTokens(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+	for _, size := range []int{1, 10, 100} {
+		data := strings.Repeat(query, size)
+		b.Run(fmt.Sprintf("regexp/%d", size), func(b *testing.B) {
+			l := regexpLexer()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, err := l.GetTokens(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,683 @@
+package sqlparse
+
+import "strings"
+
+// parseQuery parses a SELECT with its WITH clause and the clauses applying to its whole result.
+func (p *parser) parseQuery() (*Query, error) {
+	start := p.peek()
+	var q Query
+	var err error
+
+	if q.With, q.Recursive, err = p.parseWith(); err != nil {
+		return nil, err
+	}
+	if q.Body, err = p.parseSetOperation(); err != nil {
+		return nil, err
+	}
+
+	if p.acceptKeyword("ORDER BY") {
+		if q.OrderBy, err = p.parseOrderItems(); err != nil {
+			return nil, err
+		}
+	}
+
+	// LIMIT and OFFSET are accepted in any order, as in PostgreSQL
+	for {
+		switch {
+		case q.Limit == nil && p.acceptKeyword("LIMIT"):
+			if q.Limit, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+			// LIMIT offset, count as in MySQL
+			if q.Offset == nil && p.acceptPunct(",") {
+				q.Offset = q.Limit
+				if q.Limit, err = p.parseExpr(); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		case q.Offset == nil && p.acceptKeyword("OFFSET"):
+			if q.Offset, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+			p.acceptKeyword("ROW", "ROWS")
+			continue
+		}
+		break
+	}
+
+	if p.isKeyword("FETCH") {
+		if q.Fetch, err = p.parseFetch(); err != nil {
+			return nil, err
+		}
+	}
+
+	for p.isKeyword("FOR") {
+		lock, err := p.parseLock()
+		if err != nil {
+			return nil, err
+		}
+		q.Locks = append(q.Locks, lock)
+	}
+
+	q.Span = p.span(start)
+	return &q, nil
+}
+
+// parseWith parses an optional WITH [RECURSIVE] clause.
+func (p *parser) parseWith() (ctes []*CommonTableExpr, recursive bool, err error) {
+	if !p.acceptKeyword("WITH") {
+		return nil, false, nil
+	}
+	recursive = p.acceptWord("RECURSIVE")
+	for {
+		cte, err := p.parseCommonTableExpr()
+		if err != nil {
+			return nil, false, err
+		}
+		ctes = append(ctes, cte)
+		if !p.acceptPunct(",") {
+			return ctes, recursive, nil
+		}
+	}
+}
+
+// parseCommonTableExpr parses name [(columns)] AS (query).
+func (p *parser) parseCommonTableExpr() (*CommonTableExpr, error) {
+	start := p.peek()
+	var cte CommonTableExpr
+	var err error
+
+	if cte.Name, err = p.parseIdent(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("(") {
+		if cte.Columns, err = p.parseIdentList(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	if cte.Query, err = p.parseSubquery(); err != nil {
+		return nil, err
+	}
+
+	cte.Span = p.span(start)
+	return &cte, nil
+}
+
+// parseSubquery parses a query between parentheses.
+func (p *parser) parseSubquery() (*Query, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	return q, p.expectPunct(")")
+}
+
+// parseSetOperation parses queries combined with UNION and EXCEPT, which bind less tightly than INTERSECT.
+func (p *parser) parseSetOperation() (QueryBody, error) {
+	start := p.peek()
+	left, err := p.parseIntersect()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("UNION", "UNION ALL", "UNION DISTINCT", "EXCEPT", "EXCEPT ALL", "EXCEPT DISTINCT") {
+		operator := p.next().Normalized()
+		right, err := p.parseIntersect()
+		if err != nil {
+			return nil, err
+		}
+		left = &SetOperation{Span: p.span(start), Operator: operator, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseIntersect() (QueryBody, error) {
+	start := p.peek()
+	left, err := p.parseQueryPrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("INTERSECT", "INTERSECT ALL", "INTERSECT DISTINCT") {
+		operator := p.next().Normalized()
+		right, err := p.parseQueryPrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = &SetOperation{Span: p.span(start), Operator: operator, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseQueryPrimary parses a SELECT, a VALUES list or a query between parentheses.
+func (p *parser) parseQueryPrimary() (QueryBody, error) {
+	if p.isPunct("(") {
+		return p.parseSubquery()
+	}
+	if p.isKeyword("VALUES") {
+		return p.parseValues()
+	}
+	return p.parseSelect()
+}
+
+// parseValues parses a VALUES list used as a query.
+func (p *parser) parseValues() (*Values, error) {
+	start, err := p.expectKeyword("VALUES")
+	if err != nil {
+		return nil, err
+	}
+	var v Values
+	if v.Rows, err = p.parseValuesRows(); err != nil {
+		return nil, err
+	}
+	v.Span = p.span(start)
+	return &v, nil
+}
+
+// parseValuesRows parses the parenthesised rows following VALUES, separated by commas.
+func (p *parser) parseValuesRows() ([][]Expr, error) {
+	var rows [][]Expr
+	for {
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		row, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+		if !p.acceptPunct(",") {
+			return rows, nil
+		}
+	}
+}
+
+// parseSelect parses SELECT up to its HAVING clause.
+func (p *parser) parseSelect() (*Select, error) {
+	start, err := p.expectKeyword("SELECT")
+	if err != nil {
+		return nil, err
+	}
+	var s Select
+
+	if p.acceptKeyword("DISTINCT") {
+		s.Distinct = true
+		if p.acceptKeyword("ON") {
+			if s.DistinctOn, err = p.parseParenExprList(); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		p.acceptKeyword("ALL")
+	}
+
+	if s.Columns, err = p.parseSelectItems(); err != nil {
+		return nil, err
+	}
+
+	if p.acceptKeyword("FROM") {
+		if s.From, err = p.parseTableExprs(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("WHERE") {
+		if s.Where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("GROUP BY") {
+		if s.GroupBy, err = p.parseGroupingElements(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("HAVING") {
+		if s.Having, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("WINDOW") {
+		for {
+			window, err := p.parseNamedWindow()
+			if err != nil {
+				return nil, err
+			}
+			s.Windows = append(s.Windows, window)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	}
+
+	s.Span = p.span(start)
+	return &s, nil
+}
+
+// parseGroupingElements parses the elements of a GROUP BY clause, separated by commas.
+func (p *parser) parseGroupingElements() ([]Expr, error) {
+	var elems []Expr
+	for {
+		elem, err := p.parseGroupingElement()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+		if !p.acceptPunct(",") {
+			return elems, nil
+		}
+	}
+}
+
+// parseGroupingElement parses an expression, ROLLUP (...), CUBE (...), GROUPING SETS (...) or the empty grouping set
+// ().
+func (p *parser) parseGroupingElement() (Expr, error) {
+	start := p.peek()
+	var e GroupingExpr
+	var err error
+
+	switch next := p.peekAt(1); {
+	case p.isPunct("(") && next.Type == TokenPunctuation && next.Value == ")":
+		p.pos += 2
+		return &TupleExpr{Span: p.span(start)}, nil
+	case p.isKeyword("ROLLUP", "CUBE") && next.Type == TokenPunctuation && next.Value == "(":
+		e.Kind = p.next().Normalized()
+	case p.isKeyword("GROUPING") && isWordToken(next, "SETS"):
+		p.pos += 2
+		e.Kind = "GROUPING SETS"
+	default:
+		return p.parseExpr()
+	}
+
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	if e.Elems, err = p.parseGroupingElements(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	e.Span = p.span(start)
+	return &e, nil
+}
+
+// parseNamedWindow parses a window of the WINDOW clause: name AS (specification).
+func (p *parser) parseNamedWindow() (*NamedWindow, error) {
+	start := p.peek()
+	var w NamedWindow
+	var err error
+
+	if w.Name, err = p.parseIdent(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	if !p.isPunct("(") {
+		return nil, p.errorf("expected \"(\", found %s", p.describe())
+	}
+	if w.Spec, err = p.parseWindowSpec(); err != nil {
+		return nil, err
+	}
+
+	w.Span = p.span(start)
+	return &w, nil
+}
+
+// parseSelectItems parses the items of a select list or of a RETURNING clause, separated by commas.
+func (p *parser) parseSelectItems() ([]*SelectItem, error) {
+	var items []*SelectItem
+	for {
+		item, err := p.parseSelectItem()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if !p.acceptPunct(",") {
+			return items, nil
+		}
+	}
+}
+
+// parseSelectItem parses an expression of the select list with its alias.
+func (p *parser) parseSelectItem() (*SelectItem, error) {
+	start := p.peek()
+	var item SelectItem
+	var err error
+
+	if item.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if item.Alias, err = p.parseAlias(); err != nil {
+		return nil, err
+	}
+
+	item.Span = p.span(start)
+	return &item, nil
+}
+
+// parseAlias parses an optional alias, with or without AS. After AS, any keyword is an alias, as in count(*) AS count.
+// Without AS, the clauses that are non-reserved keywords in some dialects, such as RETURNING, aren't aliases.
+func (p *parser) parseAlias() (*Ident, error) {
+	if p.acceptKeyword("AS") {
+		if token := p.peek(); token.Type == TokenKeyword && !strings.ContainsAny(token.Value, " \t\r\n") {
+			p.pos++
+			return &Ident{Span: Span{Start: token.Start, End: token.End}, Name: token.Identifier()}, nil
+		}
+		return p.parseIdent()
+	}
+	if p.isIdent(p.peek()) && !p.isWord("RETURNING") {
+		return p.parseIdent()
+	}
+	return nil, nil
+}
+
+// parseTableExprs parses the tables of a FROM clause, separated by commas.
+func (p *parser) parseTableExprs() ([]TableExpr, error) {
+	var tables []TableExpr
+	for {
+		table, err := p.parseTableExpr()
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+		if !p.acceptPunct(",") {
+			return tables, nil
+		}
+	}
+}
+
+// parseTableExpr parses a table followed by any number of joins.
+func (p *parser) parseTableExpr() (TableExpr, error) {
+	start := p.peek()
+	left, err := p.parseTablePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for isJoin(p.peek()) {
+		join := Join{Type: p.next().Normalized(), Left: left}
+		if join.Right, err = p.parseTablePrimary(); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.acceptKeyword("ON"):
+			if join.On, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		case p.acceptKeyword("USING"):
+			if join.Using, err = p.parseIdentList(); err != nil {
+				return nil, err
+			}
+		}
+
+		join.Span = p.span(start)
+		left = &join
+	}
+	return left, nil
+}
+
+// parseTablePrimary parses a table name, a derived table, or joins between parentheses, with its alias.
+func (p *parser) parseTablePrimary() (TableExpr, error) {
+	start := p.peek()
+	lateral := p.acceptKeyword("LATERAL")
+
+	if p.isPunct("(") {
+		if lateral || p.isSubquery(0) {
+			return p.parseDerivedTable(start, lateral)
+		}
+
+		p.pos++
+		table, err := p.parseTableExpr()
+		if err != nil {
+			return nil, err
+		}
+		return table, p.expectPunct(")")
+	}
+
+	if p.isTableFunc() {
+		return p.parseTableFunc(start, lateral)
+	}
+	if lateral {
+		return nil, p.errorf("expected a subquery or a function, found %s", p.describe())
+	}
+	return p.parseTableName()
+}
+
+// parseDerivedTable parses a subquery used as a table, with its alias. start is the first token, LATERAL when lateral.
+func (p *parser) parseDerivedTable(start Token, lateral bool) (*DerivedTable, error) {
+	table := DerivedTable{Lateral: lateral}
+	var err error
+	if table.Query, err = p.parseSubquery(); err != nil {
+		return nil, err
+	}
+	if table.Alias, table.Columns, err = p.parseTableAlias(); err != nil {
+		return nil, err
+	}
+	table.Span = p.span(start)
+	return &table, nil
+}
+
+// parseTableAlias parses the optional alias of a derived table or a table function, with its optional column aliases,
+// as in AS s (a, b).
+func (p *parser) parseTableAlias() (alias *Ident, columns []*Ident, err error) {
+	if alias, err = p.parseAlias(); err != nil || alias == nil {
+		return nil, nil, err
+	}
+	if p.isPunct("(") {
+		if columns, err = p.parseIdentList(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return alias, columns, nil
+}
+
+// isSubquery reports whether the parentheses starting n tokens after the current one hold a query, rather than joined
+// tables. ((SELECT 1) UNION (SELECT 2)) is a query, but ((SELECT 1) x JOIN t ON true) and ((a JOIN b)) are joins.
+func (p *parser) isSubquery(n int) bool {
+	next := p.peekAt(n + 1)
+	if isKeywordToken(next, "SELECT", "WITH", "VALUES") {
+		return true
+	}
+	if next.Type != TokenPunctuation || next.Value != "(" || !p.isSubquery(n+1) {
+		return false
+	}
+
+	// the inner query must be followed by the end of the outer one, or by what may follow a query
+	depth := 0
+	for i := n + 1; i < len(p.tokens)-p.pos; i++ {
+		token := p.peekAt(i)
+		if token.Type != TokenPunctuation {
+			continue
+		}
+		switch token.Value {
+		case "(":
+			depth++
+		case ")":
+			if depth--; depth == 0 {
+				after := p.peekAt(i + 1)
+				return after.Type == TokenPunctuation && after.Value == ")" || isKeywordToken(after, querySuffixKeywords...)
+			}
+		}
+	}
+	return false
+}
+
+// querySuffixKeywords are the keywords that may follow a parenthesised query within another query.
+var querySuffixKeywords = []string{
+	"UNION", "UNION ALL", "UNION DISTINCT", "EXCEPT", "EXCEPT ALL", "EXCEPT DISTINCT", "INTERSECT", "INTERSECT ALL",
+	"INTERSECT DISTINCT", "ORDER BY", "LIMIT", "OFFSET", "FETCH", "FOR",
+}
+
+// isTableFunc reports whether a function call starts at the current token, rather than a table name.
+func (p *parser) isTableFunc() bool {
+	token := p.peek()
+	if !p.isIdent(token) && (token.Type != TokenKeyword || !p.isFuncKeyword(token)) {
+		return false
+	}
+	i := 1
+	for p.peekAt(i).Type == TokenPunctuation && p.peekAt(i).Value == "." {
+		i += 2
+	}
+	next := p.peekAt(i)
+	return next.Type == TokenPunctuation && next.Value == "("
+}
+
+// parseTableFunc parses a function used as a table, as in generate_series(1, 10) AS g, with its alias. start is the
+// first token, LATERAL when lateral.
+func (p *parser) parseTableFunc(start Token, lateral bool) (*TableFunc, error) {
+	table := TableFunc{Lateral: lateral}
+	call, err := p.parseNameOrCall()
+	if err != nil {
+		return nil, err
+	}
+	table.Func = call.(*FuncExpr)
+	if table.Alias, table.Columns, err = p.parseTableAlias(); err != nil {
+		return nil, err
+	}
+	table.Span = p.span(start)
+	return &table, nil
+}
+
+// parseTableName parses a table name with its alias.
+func (p *parser) parseTableName() (*TableName, error) {
+	start := p.peek()
+	var table TableName
+	var err error
+
+	if table.Name, err = p.parseObjectName(); err != nil {
+		return nil, err
+	}
+	if table.Alias, err = p.parseAlias(); err != nil {
+		return nil, err
+	}
+
+	table.Span = p.span(start)
+	return &table, nil
+}
+
+// parseOrderItem parses expr [ASC|DESC] [NULLS FIRST|LAST].
+func (p *parser) parseOrderItem() (*OrderItem, error) {
+	start := p.peek()
+	var item OrderItem
+	var err error
+
+	if item.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if err := p.parseOrderDirection(&item); err != nil {
+		return nil, err
+	}
+
+	item.Span = p.span(start)
+	return &item, nil
+}
+
+// parseOrderDirection parses the [ASC|DESC] [NULLS FIRST|LAST] following the expression of an order item.
+func (p *parser) parseOrderDirection(item *OrderItem) error {
+	if p.isWord("ASC", "DESC") {
+		item.Direction = strings.ToUpper(p.next().Value)
+	}
+	if p.acceptWord("NULLS") {
+		if !p.isWord("FIRST", "LAST") {
+			return p.errorf("expected FIRST or LAST, found %s", p.describe())
+		}
+		item.Nulls = strings.ToUpper(p.next().Value)
+	}
+	return nil
+}
+
+// parseFetch parses FETCH FIRST|NEXT [count] ROW|ROWS ONLY|WITH TIES.
+func (p *parser) parseFetch() (*Fetch, error) {
+	start := p.next()
+	var fetch Fetch
+	var err error
+
+	if !p.acceptWord("FIRST", "NEXT") {
+		return nil, p.errorf("expected FIRST or NEXT, found %s", p.describe())
+	}
+	if !p.isKeyword("ROW", "ROWS") {
+		if fetch.Count, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if !p.acceptKeyword("ROW", "ROWS") {
+		return nil, p.errorf("expected ROW or ROWS, found %s", p.describe())
+	}
+	if p.acceptKeyword("WITH") {
+		if err := p.expectWord("TIES"); err != nil {
+			return nil, err
+		}
+		fetch.WithTies = true
+	} else if _, err := p.expectKeyword("ONLY"); err != nil {
+		return nil, err
+	}
+
+	fetch.Span = p.span(start)
+	return &fetch, nil
+}
+
+// parseLock parses FOR UPDATE|NO KEY UPDATE|SHARE|KEY SHARE, followed by OF tables and NOWAIT or SKIP LOCKED.
+func (p *parser) parseLock() (*Lock, error) {
+	start, err := p.expectKeyword("FOR")
+	if err != nil {
+		return nil, err
+	}
+	var l Lock
+
+	switch {
+	case p.acceptKeyword("UPDATE"):
+		l.Strength = "UPDATE"
+	case p.acceptWord("SHARE"):
+		l.Strength = "SHARE"
+	case p.isKeyword("NO") && isWordToken(p.peekAt(1), "KEY") && isKeywordToken(p.peekAt(2), "UPDATE"):
+		p.pos += 3
+		l.Strength = "NO KEY UPDATE"
+	case p.isWord("KEY") && isWordToken(p.peekAt(1), "SHARE"):
+		p.pos += 2
+		l.Strength = "KEY SHARE"
+	default:
+		return nil, p.errorf("expected UPDATE or SHARE, found %s", p.describe())
+	}
+
+	if p.acceptKeyword("OF") {
+		for {
+			table, err := p.parseObjectName()
+			if err != nil {
+				return nil, err
+			}
+			l.Of = append(l.Of, table)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	}
+
+	switch {
+	case p.acceptWord("NOWAIT"):
+		l.Wait = "NOWAIT"
+	case p.isWord("SKIP") && isWordToken(p.peekAt(1), "LOCKED"):
+		p.pos += 2
+		l.Wait = "SKIP LOCKED"
+	}
+
+	l.Span = p.span(start)
+	return &l, nil
+}
+
+// isJoin reports whether the token is a join keyword, such as JOIN or LEFT OUTER JOIN.
+func isJoin(t Token) bool {
+	return (t.Type == TokenKeyword || t.Type == TokenKeywordCTE) && strings.HasSuffix(t.Normalized(), "JOIN")
+}
@@ -0,0 +1,406 @@
+package sqlparse
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// parseQuery parses data, which must be a query.
+func parseQuery(t *testing.T, data string) *Query {
+	t.Helper()
+	stmt, err := Parse(data)
+	require.NoError(t, err, "Parse")
+	require.IsType(t, &Query{}, stmt)
+	return stmt.(*Query)
+}
+
+func TestParseSelect(t *testing.T) {
+	const query = "SELECT DISTINCT a, b + 1 AS total, c \"note\" FROM t WHERE a > 1 AND b IN (1, 2) GROUP BY a, b HAVING count(*) > 1"
+	q := parseQuery(t, query)
+	assert.Equal(t, query, q.Text(query), "Query")
+
+	require.IsType(t, &Select{}, q.Body)
+	s := q.Body.(*Select)
+	assert.True(t, s.Distinct, "Distinct")
+
+	var columns, aliases []string
+	for _, item := range s.Columns {
+		columns = append(columns, item.Expr.Pos().Text(query))
+		if item.Alias != nil {
+			aliases = append(aliases, item.Alias.Name)
+		}
+	}
+	assert.Equal(t, []string{"a", "b + 1", "c"}, columns, "Columns")
+	assert.Equal(t, []string{"total", "note"}, aliases, "aliases")
+	assert.True(t, s.Columns[2].Alias.Quoted, "Quoted")
+	assert.Equal(t, "b + 1 AS total", s.Columns[1].Text(query), "SelectItem")
+
+	require.Len(t, s.From, 1, "From")
+	require.IsType(t, &TableName{}, s.From[0])
+	assert.Equal(t, "t", s.From[0].(*TableName).Name.String(), "TableName")
+
+	assert.Equal(t, "a > 1 AND b IN (1, 2)", s.Where.Pos().Text(query), "Where")
+	require.Len(t, s.GroupBy, 2, "GroupBy")
+	assert.Equal(t, "b", s.GroupBy[1].Pos().Text(query), "GroupBy")
+	assert.Equal(t, "count(*) > 1", s.Having.Pos().Text(query), "Having")
+}
+
+func TestParseDistinctOn(t *testing.T) {
+	const query = "SELECT DISTINCT ON (a, b + 1) a, c FROM t ORDER BY a"
+	s := parseQuery(t, query).Body.(*Select)
+	assert.True(t, s.Distinct, "Distinct")
+	require.Len(t, s.DistinctOn, 2, "DistinctOn")
+	assert.Equal(t, "b + 1", s.DistinctOn[1].Pos().Text(query), "DistinctOn")
+	assert.Len(t, s.Columns, 2, "Columns")
+
+	s = parseQuery(t, "SELECT DISTINCT a FROM t").Body.(*Select)
+	assert.Nil(t, s.DistinctOn, "DistinctOn")
+}
+
+func TestParseJoins(t *testing.T) {
+	const query = "SELECT * FROM s.a AS x LEFT OUTER JOIN b y ON x.id = y.id CROSS JOIN c JOIN d USING (id, k), " +
+		"LATERAL (SELECT 1) AS e, (f NATURAL JOIN g)"
+	q := parseQuery(t, query)
+	s := q.Body.(*Select)
+	require.Len(t, s.From, 3, "From")
+
+	// joins are left associative
+	require.IsType(t, &Join{}, s.From[0])
+	using := s.From[0].(*Join)
+	assert.Equal(t, "JOIN", using.Type, "Type")
+	assert.Equal(t, []string{"id", "k"}, []string{using.Using[0].Name, using.Using[1].Name}, "Using")
+
+	require.IsType(t, &Join{}, using.Left)
+	cross := using.Left.(*Join)
+	assert.Equal(t, "CROSS JOIN", cross.Type, "Type")
+	assert.Nil(t, cross.On, "On")
+
+	require.IsType(t, &Join{}, cross.Left)
+	left := cross.Left.(*Join)
+	assert.Equal(t, "LEFT OUTER JOIN", left.Type, "Type")
+	assert.Equal(t, "x.id = y.id", left.On.Pos().Text(query), "On")
+	assert.Equal(t, "s.a AS x LEFT OUTER JOIN b y ON x.id = y.id", left.Text(query), "Join")
+
+	require.IsType(t, &TableName{}, left.Left)
+	table := left.Left.(*TableName)
+	assert.Equal(t, "s.a", table.Name.String(), "Name")
+	assert.Equal(t, "x", table.Alias.Name, "Alias")
+	assert.Equal(t, "y", left.Right.(*TableName).Alias.Name, "Alias")
+
+	require.IsType(t, &DerivedTable{}, s.From[1])
+	derived := s.From[1].(*DerivedTable)
+	assert.True(t, derived.Lateral, "Lateral")
+	assert.Equal(t, "e", derived.Alias.Name, "Alias")
+	assert.Equal(t, "SELECT 1", derived.Query.Text(query), "Query")
+	assert.Equal(t, "LATERAL (SELECT 1) AS e", derived.Text(query), "DerivedTable")
+
+	require.IsType(t, &Join{}, s.From[2])
+	assert.Equal(t, "NATURAL JOIN", s.From[2].(*Join).Type, "Type")
+}
+
+func TestParseParenthesisedTables(t *testing.T) {
+	tests := []struct {
+		query string
+		types []string
+	}{
+		{query: "SELECT * FROM (SELECT 1) x", types: []string{"*sqlparse.DerivedTable"}},
+		{query: "SELECT * FROM ((SELECT 1) UNION (SELECT 2)) x", types: []string{"*sqlparse.DerivedTable"}},
+		{query: "SELECT * FROM (((SELECT 1)) ORDER BY 1) x", types: []string{"*sqlparse.DerivedTable"}},
+		{query: "SELECT * FROM ((SELECT 1) x JOIN t ON true)", types: []string{"*sqlparse.Join", "*sqlparse.DerivedTable"}},
+		{query: "SELECT * FROM (((SELECT 1) AS x CROSS JOIN t))", types: []string{"*sqlparse.Join", "*sqlparse.DerivedTable"}},
+		{query: "SELECT * FROM ((a JOIN b ON true))", types: []string{"*sqlparse.Join", "*sqlparse.TableName"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			s := parseQuery(t, test.query).Body.(*Select)
+			require.Len(t, s.From, 1, "From")
+			types := []string{fmt.Sprintf("%T", s.From[0])}
+			if join, ok := s.From[0].(*Join); ok {
+				types = append(types, fmt.Sprintf("%T", join.Left))
+			}
+			assert.Equal(t, test.types, types, "From")
+		})
+	}
+}
+
+func TestParseTableFunc(t *testing.T) {
+	const query = "SELECT * FROM generate_series(1, 10) AS g, pg_catalog.unnest(a) u, t CROSS JOIN LATERAL UNNEST(t.a) AS v"
+	s := parseQuery(t, query).Body.(*Select)
+	require.Len(t, s.From, 3, "From")
+
+	require.IsType(t, &TableFunc{}, s.From[0])
+	series := s.From[0].(*TableFunc)
+	assert.Equal(t, "generate_series", series.Func.Name.String(), "Func")
+	assert.Len(t, series.Func.Args, 2, "Args")
+	assert.Equal(t, "g", series.Alias.Name, "Alias")
+	assert.Equal(t, "generate_series(1, 10) AS g", series.Text(query), "TableFunc")
+
+	require.IsType(t, &TableFunc{}, s.From[1])
+	assert.Equal(t, "pg_catalog.unnest", s.From[1].(*TableFunc).Func.Name.String(), "Func")
+
+	require.IsType(t, &Join{}, s.From[2])
+	require.IsType(t, &TableFunc{}, s.From[2].(*Join).Right)
+	unnest := s.From[2].(*Join).Right.(*TableFunc)
+	assert.True(t, unnest.Lateral, "Lateral")
+	assert.Equal(t, "LATERAL UNNEST(t.a) AS v", unnest.Text(query), "TableFunc")
+
+	_, err := Parse("SELECT * FROM LATERAL t")
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, `expected a subquery or a function, found "t"`, syntaxErr.Msg, "Msg")
+}
+
+func TestParseQueryClauses(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		orderBy []string
+		limit   string
+		offset  string
+		fetch   string
+	}{
+		{
+			name:    "order by",
+			dialect: ANSI,
+			query:   "SELECT a FROM t ORDER BY a DESC NULLS LAST, b ASC, c",
+			orderBy: []string{"a DESC NULLS LAST", "b ASC", "c"},
+		},
+		{
+			name:    "limit offset",
+			dialect: PostgreSQL,
+			query:   "SELECT a FROM t ORDER BY a LIMIT 10 OFFSET $1",
+			orderBy: []string{"a"},
+			limit:   "10",
+			offset:  "$1",
+		},
+		{
+			name:    "offset limit",
+			dialect: PostgreSQL,
+			query:   "SELECT a FROM t OFFSET 5 ROWS LIMIT 1 + 1",
+			limit:   "1 + 1",
+			offset:  "5",
+		},
+		{
+			name:    "mysql limit",
+			dialect: MySQL,
+			query:   "SELECT a FROM t LIMIT 20, 10",
+			limit:   "10",
+			offset:  "20",
+		},
+		{
+			name:    "fetch",
+			dialect: ANSI,
+			query:   "SELECT a FROM t ORDER BY a OFFSET 1 ROW FETCH NEXT 5 ROWS ONLY",
+			orderBy: []string{"a"},
+			offset:  "1",
+			fetch:   "FETCH NEXT 5 ROWS ONLY",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stmt, err := NewLexer(test.dialect).Parse(test.query)
+			require.NoError(t, err, "Parse")
+			q := stmt.(*Query)
+
+			var orderBy []string
+			for _, item := range q.OrderBy {
+				orderBy = append(orderBy, item.Text(test.query))
+			}
+			assert.Equal(t, test.orderBy, orderBy, "OrderBy")
+
+			text := func(n Node) string {
+				if n == nil {
+					return ""
+				}
+				return n.Pos().Text(test.query)
+			}
+			assert.Equal(t, test.limit, text(q.Limit), "Limit")
+			assert.Equal(t, test.offset, text(q.Offset), "Offset")
+			if test.fetch == "" {
+				assert.Nil(t, q.Fetch, "Fetch")
+			} else {
+				assert.Equal(t, test.fetch, text(q.Fetch), "Fetch")
+			}
+		})
+	}
+}
+
+func TestParseOrderItem(t *testing.T) {
+	q := parseQuery(t, "SELECT a FROM t ORDER BY a desc nulls first, b")
+	require.Len(t, q.OrderBy, 2, "OrderBy")
+	assert.Equal(t, "DESC", q.OrderBy[0].Direction, "Direction")
+	assert.Equal(t, "FIRST", q.OrderBy[0].Nulls, "Nulls")
+	assert.Empty(t, q.OrderBy[1].Direction, "Direction")
+}
+
+func TestParseFetch(t *testing.T) {
+	q := parseQuery(t, "SELECT a FROM t FETCH FIRST ROW WITH TIES")
+	require.NotNil(t, q.Fetch, "Fetch")
+	assert.Nil(t, q.Fetch.Count, "Count")
+	assert.True(t, q.Fetch.WithTies, "WithTies")
+}
+
+func TestParseWith(t *testing.T) {
+	const query = "WITH RECURSIVE r (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r), s AS (SELECT 2) SELECT n FROM r"
+	q := parseQuery(t, query)
+	assert.True(t, q.Recursive, "Recursive")
+	require.Len(t, q.With, 2, "With")
+
+	r := q.With[0]
+	assert.Equal(t, "r", r.Name.Name, "Name")
+	require.Len(t, r.Columns, 1, "Columns")
+	assert.Equal(t, "n", r.Columns[0].Name, "Columns")
+	assert.Equal(t, "r (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r)", r.Text(query), "CommonTableExpr")
+
+	require.IsType(t, &SetOperation{}, r.Query.Body)
+	assert.Equal(t, "UNION ALL", r.Query.Body.(*SetOperation).Operator, "Operator")
+	assert.Equal(t, "s", q.With[1].Name.Name, "Name")
+	assert.Equal(t, "SELECT n FROM r", q.Body.Pos().Text(query), "Body")
+}
+
+func TestParseSetOperations(t *testing.T) {
+	const query = "(SELECT 1) UNION SELECT 2 INTERSECT SELECT 3 EXCEPT ALL SELECT 4 ORDER BY 1"
+	q := parseQuery(t, query)
+	require.Len(t, q.OrderBy, 1, "OrderBy")
+
+	// INTERSECT binds more tightly than UNION and EXCEPT
+	require.IsType(t, &SetOperation{}, q.Body)
+	except := q.Body.(*SetOperation)
+	assert.Equal(t, "EXCEPT ALL", except.Operator, "Operator")
+	assert.Equal(t, "(SELECT 1) UNION SELECT 2 INTERSECT SELECT 3 EXCEPT ALL SELECT 4", except.Text(query), "SetOperation")
+
+	require.IsType(t, &SetOperation{}, except.Left)
+	union := except.Left.(*SetOperation)
+	assert.Equal(t, "UNION", union.Operator, "Operator")
+	require.IsType(t, &Query{}, union.Left)
+	assert.Equal(t, "SELECT 1", union.Left.Pos().Text(query), "Left")
+
+	require.IsType(t, &SetOperation{}, union.Right)
+	assert.Equal(t, "SELECT 2 INTERSECT SELECT 3", union.Right.Pos().Text(query), "Right")
+}
+
+func TestParseAliasKeyword(t *testing.T) {
+	const query = "SELECT count(*) AS count, min(a) AS min, a AS select FROM t AS order"
+	s := parseQuery(t, query).Body.(*Select)
+
+	var aliases []string
+	for _, item := range s.Columns {
+		aliases = append(aliases, item.Alias.Name)
+	}
+	assert.Equal(t, []string{"count", "min", "select"}, aliases, "aliases")
+	assert.Equal(t, "order", s.From[0].(*TableName).Alias.Name, "Alias")
+}
+
+func TestParseGroupingSets(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "SELECT a, b FROM t GROUP BY ROLLUP (a, b)", expected: []string{"ROLLUP [a b]"}},
+		{query: "SELECT a, b FROM t GROUP BY a, CUBE (b, (c, d))", expected: []string{"a", "CUBE [b (c, d)]"}},
+		{query: "SELECT a, b FROM t GROUP BY GROUPING SETS ((a, b), a, ())", expected: []string{"GROUPING SETS [(a, b) a ()]"}},
+		{query: "SELECT a FROM t GROUP BY GROUPING SETS (ROLLUP (a), b)", expected: []string{"GROUPING SETS [ROLLUP (a) b]"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			s := parseQuery(t, test.query).Body.(*Select)
+			var groupBy []string
+			for _, elem := range s.GroupBy {
+				grouping, ok := elem.(*GroupingExpr)
+				if !ok {
+					groupBy = append(groupBy, elem.Pos().Text(test.query))
+					continue
+				}
+				var elems []string
+				for _, e := range grouping.Elems {
+					elems = append(elems, e.Pos().Text(test.query))
+				}
+				groupBy = append(groupBy, fmt.Sprintf("%s %v", grouping.Kind, elems))
+			}
+			assert.Equal(t, test.expected, groupBy, "GroupBy")
+		})
+	}
+}
+
+func TestParseWindowClause(t *testing.T) {
+	const query = "SELECT sum(a) OVER w, rank() OVER (v ORDER BY b) FROM t WINDOW w AS (PARTITION BY c), v AS (w ORDER BY d)"
+	s := parseQuery(t, query).Body.(*Select)
+	require.Len(t, s.Windows, 2, "Windows")
+
+	w := s.Windows[0]
+	assert.Equal(t, "w", w.Name.Name, "Name")
+	assert.Len(t, w.Spec.PartitionBy, 1, "PartitionBy")
+	assert.Equal(t, "w AS (PARTITION BY c)", w.Text(query), "NamedWindow")
+	assert.Equal(t, "w", s.Windows[1].Spec.Name.Name, "Name")
+
+	_, err := Parse("SELECT a FROM t WINDOW w AS v")
+	assert.EqualError(t, err, `1:29: expected "(", found "v"`)
+}
+
+func TestParseLocks(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		query    string
+		expected []Lock
+	}{
+		{dialect: ANSI, query: "SELECT a FROM t FOR UPDATE", expected: []Lock{{Strength: "UPDATE"}}},
+		{dialect: MySQL, query: "SELECT a FROM t LIMIT 1 FOR SHARE SKIP LOCKED", expected: []Lock{{Strength: "SHARE", Wait: "SKIP LOCKED"}}},
+		{
+			dialect:  PostgreSQL,
+			query:    "SELECT a FROM t JOIN u ON true FOR NO KEY UPDATE OF t, u NOWAIT FOR KEY SHARE",
+			expected: []Lock{{Strength: "NO KEY UPDATE", Wait: "NOWAIT"}, {Strength: "KEY SHARE"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := NewLexer(test.dialect).Parse(test.query)
+			require.NoError(t, err, "Parse")
+			q := stmt.(*Query)
+			require.Len(t, q.Locks, len(test.expected), "Locks")
+			for i, lock := range q.Locks {
+				assert.Equal(t, test.expected[i].Strength, lock.Strength, "Strength")
+				assert.Equal(t, test.expected[i].Wait, lock.Wait, "Wait")
+			}
+		})
+	}
+
+	q := parseQuery(t, "SELECT a FROM t FOR UPDATE OF t, s.u")
+	require.Len(t, q.Locks[0].Of, 2, "Of")
+	assert.Equal(t, "s.u", q.Locks[0].Of[1].String(), "Of")
+}
+
+func TestParseTableColumnAliases(t *testing.T) {
+	const query = "SELECT * FROM (SELECT 1, 2) AS s (y, z), (VALUES (1, 2), (3, 4)) AS v(a, b), generate_series(1, 3) g (n)"
+	s := parseQuery(t, query).Body.(*Select)
+	require.Len(t, s.From, 3, "From")
+
+	names := func(idents []*Ident) []string {
+		var names []string
+		for _, ident := range idents {
+			names = append(names, ident.Name)
+		}
+		return names
+	}
+
+	derived := s.From[0].(*DerivedTable)
+	assert.Equal(t, []string{"y", "z"}, names(derived.Columns), "Columns")
+	assert.Equal(t, "(SELECT 1, 2) AS s (y, z)", derived.Text(query), "DerivedTable")
+
+	values := s.From[1].(*DerivedTable)
+	assert.Equal(t, []string{"a", "b"}, names(values.Columns), "Columns")
+	require.IsType(t, &Values{}, values.Query.Body)
+	rows := values.Query.Body.(*Values).Rows
+	require.Len(t, rows, 2, "Rows")
+	assert.Len(t, rows[1], 2, "Rows")
+
+	series := s.From[2].(*TableFunc)
+	assert.Equal(t, "g", series.Alias.Name, "Alias")
+	assert.Equal(t, []string{"n"}, names(series.Columns), "Columns")
+}
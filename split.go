@@ -0,0 +1,278 @@
+package sqlparse
+
+import (
+	"slices"
+	"strings"
+)
+
+// Statement is a statement of a script, see SplitStatements.
+type Statement struct {
+	// Tokens are the tokens of the statement, without the whitespace around it nor its delimiter. Comments are kept.
+	Tokens []Token
+	// Start and End are the positions of the first and last tokens, so that data[Start.Offset:End.Offset] is the text
+	// of the statement.
+	Start Position
+	End   Position
+	// Delimiter is the text ending the statement: ";", the delimiter set by a DELIMITER command, the GO line, or empty
+	// for a statement ending the script without delimiter.
+	Delimiter string
+}
+
+// beginNotBlock are the words following a BEGIN that starts a transaction rather than a BEGIN ... END block.
+var beginNotBlock = []string{
+	"DEFERRED", "DISTRIBUTED", "EXCLUSIVE", "IMMEDIATE", "ISOLATION", "READ", "TRAN", "TRANSACTION", "WORK",
+}
+
+// endNotBlock are the words following an END that closes a control statement rather than a BEGIN ... END block.
+var endNotBlock = []string{"FOR", "IF", "LOOP", "REPEAT", "WHILE"}
+
+// SplitStatements splits a script into its statements. Statements end at a semicolon, except inside strings, comments,
+// dollar-quoted bodies and BEGIN ... END blocks, such as the body of a trigger, and at the end of data. Statements made
+// only of whitespace and comments are skipped.
+//
+// Client commands are also recognised on their own line: "DELIMITER //", as in the MySQL client, replaces the semicolon
+// by another delimiter until the next DELIMITER command, and "GO", as in SQL Server scripts, ends the current
+// statement. The command lines aren't part of any statement.
+//
+// The returned error is a *SyntaxError when part of data can't be lexed.
+func (l *Lexer) SplitStatements(data string) ([]Statement, error) {
+	scanner := l.newStringScanner(data)
+	scanner.tolerant = true
+	s := splitter{data: data, scanner: scanner, delimiter: ";"}
+
+	for i := 0; s.read(i); {
+		if end := s.clientCommand(i); end > 0 {
+			i = s.skipTo(i, end)
+			continue
+		}
+		if end := s.delimiterAt(i); end > 0 {
+			s.end(data[s.tokens[i].Start.Offset:end])
+			i = s.skipTo(i, end)
+			continue
+		}
+
+		token := s.tokens[i]
+		if token.Type == TokenError {
+			for _, diagnostic := range s.scanner.diags {
+				if diagnostic.Offset == token.Start.Offset {
+					return nil, diagnostic
+				}
+			}
+		}
+		s.block(i)
+		s.current = append(s.current, token)
+		i++
+	}
+	s.end("")
+
+	return s.statements, nil
+}
+
+// SplitStatements splits a script into its statements using the default lexer, see Lexer.SplitStatements.
+func SplitStatements(data string) ([]Statement, error) {
+	return defaultLexer().SplitStatements(data)
+}
+
+// splitter holds the state of SplitStatements.
+type splitter struct {
+	data string
+	// scanner reads the tokens as they are needed: they depend on the delimiter, which may change along the way
+	scanner    *Scanner
+	tokens     []Token // tokens read so far
+	delimiter  string
+	blocks     []string // BEGIN and CASE keywords waiting for their END
+	current    []Token  // tokens of the statement being read
+	statements []Statement
+}
+
+// clientCommand returns the end of the line of the DELIMITER or GO command starting at token i, or 0.
+func (s *splitter) clientCommand(i int) int {
+	token := s.tokens[i]
+	if !strings.EqualFold(token.Value, "DELIMITER") && !strings.EqualFold(token.Value, "GO") {
+		return 0
+	}
+
+	start := token.Start.Offset
+	lineStart := strings.LastIndexAny(s.data[:start], "\r\n") + 1
+	if strings.TrimSpace(s.data[lineStart:start]) != "" {
+		return 0
+	}
+	lineEnd := len(s.data)
+	if j := strings.IndexAny(s.data[start:], "\r\n"); j >= 0 {
+		lineEnd = start + j
+	}
+	line := strings.TrimSpace(s.data[start:lineEnd])
+
+	fields := strings.Fields(line)
+	switch {
+	case strings.EqualFold(fields[0], "DELIMITER") && len(fields) == 2:
+		s.end("")
+		s.delimiter = fields[1]
+	case strings.EqualFold(fields[0], "GO") && (len(fields) == 1 || len(fields) == 2 && isDigits(fields[1])):
+		s.end(line)
+	default:
+		return 0
+	}
+	return lineEnd
+}
+
+// delimiterAt returns the end of the delimiter starting at token i, or 0.
+func (s *splitter) delimiterAt(i int) int {
+	token := s.tokens[i]
+	if s.delimiter == ";" {
+		if token.Type == TokenPunctuation && token.Value == ";" && !slices.Contains(s.blocks, "BEGIN") {
+			return token.End.Offset
+		}
+		return 0
+	}
+
+	switch token.Type {
+	case TokenQuotedName, TokenComment, TokenCommentBlock, TokenCommentHint:
+		return 0
+	}
+	// the tokens were lexed without knowing the delimiter: it may start inside a token, as in END$$ where $ is a name
+	// character, or be lexed as the start of a dollar-quoted string
+	start := token.Start.Offset
+	k := strings.Index(s.data[start:min(token.End.Offset+len(s.delimiter)-1, len(s.data))], s.delimiter)
+	switch {
+	case k < 0 || k > 0 && token.Type == TokenString:
+		return 0
+	case k > 0:
+		s.relex(i, start, start+k)
+		return 0
+	}
+	return start + len(s.delimiter)
+}
+
+// read reports whether there is a token i, reading tokens until then.
+func (s *splitter) read(i int) bool {
+	for len(s.tokens) <= i {
+		if !s.scanner.Scan() {
+			return false
+		}
+		s.tokens = append(s.tokens, s.scanner.Token())
+	}
+	return true
+}
+
+// skipTo returns the index of the first token starting at offset, from token i on. The tokens are lexed again from
+// offset when it falls inside a token.
+func (s *splitter) skipTo(i, offset int) int {
+	for s.read(i) && s.tokens[i].End.Offset <= offset {
+		i++
+	}
+	if i < len(s.tokens) && s.tokens[i].Start.Offset < offset {
+		s.relex(i, offset, len(s.data))
+	}
+	return i
+}
+
+// relex drops the tokens read from token i on, and lexes the data again from offset from, at or after the start of
+// token i. When to is before the end of data, data[from:to] is lexed as if the data ended there.
+func (s *splitter) relex(i, from, to int) {
+	start := s.tokens[i].Start
+	var prev Token
+	for j := i - 1; j >= 0; j-- {
+		if !slices.Contains(triviaTypes, s.tokens[j].Type) && s.tokens[j].Type != TokenCommentHint {
+			prev = s.tokens[j]
+			break
+		}
+	}
+	s.tokens = s.tokens[:i]
+	diags := s.scanner.diags // in the order of the data
+	for len(diags) > 0 && diags[len(diags)-1].Offset >= start.Offset {
+		diags = diags[:len(diags)-1]
+	}
+
+	pos := start.advance(s.data[start.Offset:from])
+	if to < len(s.data) {
+		piece := s.scanner.lexer.newStringScanner(s.data[:to])
+		piece.tolerant, piece.off, piece.pos, piece.prev = true, from, pos, prev
+		for piece.Scan() {
+			s.tokens = append(s.tokens, piece.Token())
+		}
+		diags = append(diags, piece.diags...)
+		from, pos, prev = to, piece.pos, piece.prev
+	}
+	s.scanner.off, s.scanner.pos, s.scanner.prev, s.scanner.diags = from, pos, prev, diags
+}
+
+// block keeps track of the BEGIN ... END blocks, given the keyword at token i. CASE expressions are tracked too, as
+// they also end with END.
+func (s *splitter) block(i int) {
+	token := s.tokens[i]
+	if token.Type != TokenKeyword {
+		return
+	}
+
+	next := s.next(i)
+	switch token.Normalized() {
+	case "BEGIN":
+		if next.Value == ";" || next.Value == "" || slices.Contains(beginNotBlock, strings.ToUpper(next.Value)) {
+			return
+		}
+		s.blocks = append(s.blocks, "BEGIN")
+	case "CASE":
+		if prev := s.prev(); strings.EqualFold(prev.Value, "END") {
+			return
+		}
+		s.blocks = append(s.blocks, "CASE")
+	case "END":
+		if len(s.blocks) == 0 || slices.Contains(endNotBlock, strings.ToUpper(next.Value)) {
+			return
+		}
+		s.blocks = s.blocks[:len(s.blocks)-1]
+	}
+}
+
+// next returns the token following token i that is not trivia, or an empty token.
+func (s *splitter) next(i int) Token {
+	for j := i + 1; s.read(j); j++ {
+		if !slices.Contains(triviaTypes, s.tokens[j].Type) {
+			return s.tokens[j]
+		}
+	}
+	return Token{}
+}
+
+// prev returns the last token of the current statement that is not trivia, or an empty token.
+func (s *splitter) prev() Token {
+	for i := len(s.current) - 1; i >= 0; i-- {
+		if !slices.Contains(triviaTypes, s.current[i].Type) {
+			return s.current[i]
+		}
+	}
+	return Token{}
+}
+
+// end ends the current statement with the given delimiter.
+func (s *splitter) end(delimiter string) {
+	tokens := s.current
+	s.current = nil
+	s.blocks = nil
+
+	for len(tokens) > 0 && isSpaceToken(tokens[0]) {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 0 && isSpaceToken(tokens[len(tokens)-1]) {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if !slices.ContainsFunc(tokens, func(t Token) bool { return !slices.Contains(triviaTypes, t.Type) }) {
+		return
+	}
+
+	s.statements = append(s.statements, Statement{
+		Tokens:    tokens,
+		Start:     tokens[0].Start,
+		End:       tokens[len(tokens)-1].End,
+		Delimiter: delimiter,
+	})
+}
+
+func isSpaceToken(t Token) bool {
+	return t.Type == TokenWhitespace || t.Type == TokenNewline
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
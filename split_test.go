@@ -0,0 +1,178 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    Dialect
+		script     string
+		statements []string
+		delimiters []string
+	}{
+		{
+			name:       "semicolons",
+			dialect:    ANSI,
+			script:     "SELECT 1;\n\nSELECT 2 ;  SELECT 3",
+			statements: []string{"SELECT 1", "SELECT 2", "SELECT 3"},
+			delimiters: []string{";", ";", ""},
+		},
+		{
+			name:       "strings and comments",
+			dialect:    ANSI,
+			script:     "SELECT 'a;b' -- c;d\n, \"e;f\" /* g; */;\n-- trailing; comment\n",
+			statements: []string{"SELECT 'a;b' -- c;d\n, \"e;f\" /* g; */"},
+			delimiters: []string{";"},
+		},
+		{
+			name:       "empty statements",
+			dialect:    ANSI,
+			script:     ";; SELECT 1;;",
+			statements: []string{"SELECT 1"},
+			delimiters: []string{";"},
+		},
+		{
+			name:       "dollar quoted body",
+			dialect:    PostgreSQL,
+			script:     "CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql; SELECT f();",
+			statements: []string{"CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql", "SELECT f()"},
+			delimiters: []string{";", ";"},
+		},
+		{
+			name:    "begin end block",
+			dialect: SQLite,
+			script: "CREATE TRIGGER t AFTER INSERT ON a BEGIN\n  UPDATE b SET n = CASE WHEN n > 0 THEN n + 1 ELSE 1 END;\n" +
+				"  DELETE FROM c;\nEND;\nSELECT 1;",
+			statements: []string{
+				"CREATE TRIGGER t AFTER INSERT ON a BEGIN\n  UPDATE b SET n = CASE WHEN n > 0 THEN n + 1 ELSE 1 END;\n  DELETE FROM c;\nEND",
+				"SELECT 1",
+			},
+			delimiters: []string{";", ";"},
+		},
+		{
+			name:       "transactions",
+			dialect:    PostgreSQL,
+			script:     "BEGIN; UPDATE a SET b = 1; END; BEGIN TRANSACTION; COMMIT;",
+			statements: []string{"BEGIN", "UPDATE a SET b = 1", "END", "BEGIN TRANSACTION", "COMMIT"},
+			delimiters: []string{";", ";", ";", ";", ";"},
+		},
+		{
+			name:    "mysql delimiter",
+			dialect: MySQL,
+			script: "DELIMITER //\nCREATE PROCEDURE p()\nBEGIN\n  IF 1 THEN SELECT 1; END IF;\nEND//\n" +
+				"DELIMITER $$\nCALL p()$$\ndelimiter ;\nCALL p();",
+			statements: []string{
+				"CREATE PROCEDURE p()\nBEGIN\n  IF 1 THEN SELECT 1; END IF;\nEND",
+				"CALL p()",
+				"CALL p()",
+			},
+			delimiters: []string{"//", "$$", ";"},
+		},
+		{
+			name:       "go batches",
+			dialect:    SQLServer,
+			script:     "CREATE TABLE a (go int)\nGO\nINSERT INTO a VALUES (1);\n  go 2\r\nSELECT go FROM a\n",
+			statements: []string{"CREATE TABLE a (go int)", "INSERT INTO a VALUES (1)", "SELECT go FROM a"},
+			delimiters: []string{"GO", ";", ""},
+		},
+		{
+			name:       "delimiter after a name",
+			dialect:    MySQL,
+			script:     "DELIMITER $$\nCREATE PROCEDURE p() BEGIN SELECT 1; END$$\nDELIMITER ;\nSELECT 2;",
+			statements: []string{"CREATE PROCEDURE p() BEGIN SELECT 1; END", "SELECT 2"},
+			delimiters: []string{"$$", ";"},
+		},
+		{
+			name:       "delimiter opening a dollar quoted string",
+			dialect:    ANSI,
+			script:     "DELIMITER $$\nCREATE PROCEDURE p() BEGIN SELECT 1; END$$\nDELIMITER ;\nSELECT 2;",
+			statements: []string{"CREATE PROCEDURE p() BEGIN SELECT 1; END", "SELECT 2"},
+			delimiters: []string{"$$", ";"},
+		},
+		{
+			name:       "case end case",
+			dialect:    MySQL,
+			script:     "CREATE PROCEDURE p() BEGIN CASE x WHEN 1 THEN SELECT 1; END CASE; END; SELECT 2;",
+			statements: []string{"CREATE PROCEDURE p() BEGIN CASE x WHEN 1 THEN SELECT 1; END CASE; END", "SELECT 2"},
+			delimiters: []string{";", ";"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statements, err := NewLexer(test.dialect).SplitStatements(test.script)
+			require.NoError(t, err, "SplitStatements")
+
+			var texts, delimiters []string
+			for _, statement := range statements {
+				texts = append(texts, test.script[statement.Start.Offset:statement.End.Offset])
+				delimiters = append(delimiters, statement.Delimiter)
+
+				var value string
+				for _, token := range statement.Tokens {
+					value += token.Value
+				}
+				assert.Equal(t, test.script[statement.Start.Offset:statement.End.Offset], value, "Tokens")
+			}
+			assert.Equal(t, test.statements, texts, "statements")
+			assert.Equal(t, test.delimiters, delimiters, "delimiters")
+		})
+	}
+}
+
+func TestSplitStatementsPositions(t *testing.T) {
+	statements, err := SplitStatements("SELECT 1;\n  SELECT 2")
+	require.NoError(t, err, "SplitStatements")
+
+	require.Len(t, statements, 2, "statements")
+	assert.Equal(t, Position{Offset: 12, Line: 2, Column: 3}, statements[1].Start)
+	assert.Equal(t, Position{Offset: 20, Line: 2, Column: 11}, statements[1].End)
+}
+
+func TestSplitStatementsRelexed(t *testing.T) {
+	const script = "DELIMITER $$\nSELECT 'a$$b', x$$ SELECT\n  y$$"
+	statements, err := NewLexer(MySQL).SplitStatements(script)
+	require.NoError(t, err, "SplitStatements")
+
+	require.Len(t, statements, 2, "statements")
+	assert.Equal(t, "SELECT 'a$$b', x", script[statements[0].Start.Offset:statements[0].End.Offset])
+	last := statements[1].Tokens[len(statements[1].Tokens)-1]
+	assert.Equal(t, Token{Value: "y", Type: TokenName, Start: Position{Offset: 41, Line: 3, Column: 3}, End: Position{Offset: 42, Line: 3, Column: 4}}, last)
+}
+
+func TestSplitStatementsError(t *testing.T) {
+	_, err := SplitStatements("SELECT 1;\nSELECT {")
+
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, Position{Offset: 17, Line: 2, Column: 8}, syntaxErr.Position)
+}
+
+// FuzzSplitStatements checks that the statements are in order and that their tokens match their span.
+func FuzzSplitStatements(f *testing.F) {
+	for _, script := range []string{"SELECT 1; SELECT 2", "BEGIN SELECT 1; END; x", "DELIMITER //\na//\nGO\nb", "CASE END END;", "DELIMITER $$\nEND$$"} {
+		f.Add(script)
+	}
+
+	f.Fuzz(func(t *testing.T, script string) {
+		statements, err := NewLexer(MySQL).SplitStatements(script)
+		if err != nil {
+			return
+		}
+
+		var end int
+		for _, statement := range statements {
+			require.GreaterOrEqual(t, statement.Start.Offset, end, "Start")
+			var value string
+			for _, token := range statement.Tokens {
+				value += token.Value
+			}
+			require.Equal(t, script[statement.Start.Offset:statement.End.Offset], value, "Tokens")
+			end = statement.End.Offset
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package sqlparse
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// defaultReadSize is how much the Scanner reads from its reader at a time
+	defaultReadSize = 64 * 1024
+
+	// DefaultMaxTokenSize is the default limit for the size of a single token read by a Scanner, see Scanner.Buffer.
+	DefaultMaxTokenSize = 64 * 1024 * 1024
+
+	// maxEmptyReads is how many reads returning no data nor error a Scanner accepts before giving up
+	maxEmptyReads = 100
+)
+
+// ErrTokenTooLong is returned by Scanner.Err when a token doesn't fit in the maximum token size, see Scanner.Buffer.
+var ErrTokenTooLong = errors.New("sqlparse.Scanner: token too long")
+
+// Scanner reads tokens one at a time, like bufio.Scanner does for lines. Only the token being read is kept in
+// memory, so a Scanner can lex inputs of any size as long as every token fits in the maximum token size.
+//
+// Successive calls to Scan step through the tokens, stopping at the end of the input or at the first error. After
+// Scan returns false, Err returns the error, or nil if the input was read until the end. The tokens are the same as
+// those of GetTokens, and as lossless: writing their values in order reproduces the input read so far.
+type Scanner struct {
+	lexer *Lexer
+
+	r          io.Reader
+	readBuf    []byte
+	maxToken   int
+	data       string // buffered input, where data[off:] has not been turned into tokens yet
+	off        int
+	atEOF      bool
+	pos        Position
+	tolerant   bool
+	token      Token
+	prev       Token // last token that isn't whitespace nor a comment, telling whether a keyword is used as a name
+	diags      []*SyntaxError
+	err        error
+	scanCalled bool
+}
+
+// NewScanner returns a Scanner reading tokens from r using the lexer rules.
+func (l *Lexer) NewScanner(r io.Reader) *Scanner {
+	return &Scanner{
+		lexer:    l,
+		r:        r,
+		maxToken: DefaultMaxTokenSize,
+		pos:      startPosition,
+	}
+}
+
+// NewScanner returns a Scanner reading tokens from r using the default lexer.
+func NewScanner(r io.Reader) *Scanner {
+	return defaultLexer().NewScanner(r)
+}
+
+func (l *Lexer) newStringScanner(data string) *Scanner {
+	return &Scanner{
+		lexer: l,
+		data:  data,
+		atEOF: true,
+		pos:   startPosition,
+	}
+}
+
+// Buffer sets the size of each read from the underlying reader and the maximum size of a token. It must be called
+// before the first call to Scan.
+func (s *Scanner) Buffer(readSize, maxTokenSize int) {
+	if s.scanCalled {
+		panic("sqlparse.Scanner: Buffer called after Scan")
+	}
+	s.readBuf = make([]byte, readSize)
+	s.maxToken = maxTokenSize
+}
+
+// Tolerant makes the Scanner emit input that can't be matched by any rule as TokenError tokens instead of stopping,
+// see Lexer.GetTokensTolerant. The problems are available from Diagnostics. It must be called before the first call
+// to Scan.
+func (s *Scanner) Tolerant(value bool) {
+	if s.scanCalled {
+		panic("sqlparse.Scanner: Tolerant called after Scan")
+	}
+	s.tolerant = value
+}
+
+// Token returns the token read by the last call to Scan.
+func (s *Scanner) Token() Token {
+	return s.token
+}
+
+// Err returns the first error found by the Scanner. The end of the input is not an error.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// Diagnostics returns the problems found so far by a tolerant Scanner.
+func (s *Scanner) Diagnostics() []*SyntaxError {
+	return s.diags
+}
+
+// Scan advances the Scanner to the next token, which will then be available through Token. It returns false when
+// the input is over or an error happened.
+func (s *Scanner) Scan() bool {
+	s.scanCalled = true
+
+	for s.err == nil {
+		rest := s.data[s.off:]
+		if rest == "" && s.atEOF {
+			return false
+		}
+
+		if rest != "" {
+			token, problem, more := s.lexer.process(rest, s.atEOF)
+			if !more && token.Value != "" && token.Type != TokenError {
+				isKeyword := token.Type == TokenKeyword || token.Type == TokenKeywordCTE
+				if isKeyword && s.lexer.keywordAsName(token, s.prev, rest) {
+					token.Type = TokenName
+				}
+				if token.Type == TokenParameter && isSliceColon(token, s.prev) {
+					token = Token{Value: ":", Type: TokenPunctuation}
+				}
+				s.emit(token)
+				return true
+			}
+
+			if !more {
+				if !s.tolerant {
+					s.err = s.syntaxError(problem)
+					return false
+				}
+
+				size, ok := len(token.Value), true
+				if token.Value == "" {
+					size, ok = s.errorSize(rest)
+				}
+				if ok {
+					s.diags = append(s.diags, s.syntaxError(problem))
+					s.emit(Token{Value: rest[:size], Type: TokenError})
+					return true
+				}
+			}
+		}
+
+		s.fill()
+	}
+
+	return false
+}
+
+// errorSize returns the size of the run of runes at the start of rest that can't be turned into tokens. It returns
+// false when more data is needed to know where the run ends.
+func (s *Scanner) errorSize(rest string) (int, bool) {
+	var size int
+	for {
+		if !s.atEOF && !utf8.FullRuneInString(rest[size:]) {
+			return 0, false
+		}
+		_, runeSize := utf8.DecodeRuneInString(rest[size:])
+		size += runeSize
+
+		if size == len(rest) {
+			return size, s.atEOF
+		}
+
+		token, _, more := s.lexer.process(rest[size:], s.atEOF)
+		if more {
+			return 0, false
+		}
+		if token.Value != "" {
+			return size, true
+		}
+	}
+}
+
+// syntaxError returns the error for the input at the current offset, reading ahead the rest of the line shown in its
+// snippet.
+func (s *Scanner) syntaxError(problem string) *SyntaxError {
+	for !s.atEOF && s.err == nil && len(s.data)-s.off < s.maxToken && !hasSnippetEnd(s.data[s.off:]) {
+		s.fill()
+	}
+	return newSyntaxError(s.data, s.off, s.pos, problem)
+}
+
+// hasSnippetEnd reports whether rest holds as much of its first line as the snippet of an error at its start shows.
+func hasSnippetEnd(rest string) bool {
+	for n := 0; n <= snippetContext+1; n++ {
+		if !utf8.FullRuneInString(rest) {
+			return false
+		}
+		r, size := utf8.DecodeRuneInString(rest)
+		if r == '\r' || r == '\n' {
+			return true
+		}
+		rest = rest[size:]
+	}
+	return true
+}
+
+func (s *Scanner) emit(token Token) {
+	s.off += len(token.Value)
+	if s.r != nil {
+		// don't let the token keep the whole buffer alive
+		token.Value = strings.Clone(token.Value)
+	}
+
+	token.Start = s.pos
+	token.End = s.pos.advance(token.Value)
+	s.pos = token.End
+	s.token = token
+	if !slices.Contains(triviaTypes, token.Type) && token.Type != TokenCommentHint {
+		s.prev = token
+	}
+}
+
+// fill reads more data from the reader, dropping the data already turned into tokens but the end of the line before
+// rest, shown in the snippet of an error found in rest.
+func (s *Scanner) fill() {
+	done, rest := s.data[snippetStart(s.data, s.off):s.off], s.data[s.off:]
+	if len(rest) >= s.maxToken {
+		s.err = ErrTokenTooLong
+		return
+	}
+
+	if s.readBuf == nil {
+		s.readBuf = make([]byte, defaultReadSize)
+	}
+	if len(rest) > len(s.readBuf) {
+		// the pending token is large, read more at a time to avoid copying it over and over
+		s.readBuf = make([]byte, min(2*len(rest), s.maxToken))
+	}
+
+	for emptyReads := 0; emptyReads < maxEmptyReads; emptyReads++ {
+		n, err := s.r.Read(s.readBuf)
+		if n > 0 {
+			s.data = done + rest + string(s.readBuf[:n])
+			s.off = len(done)
+		}
+		if err == io.EOF {
+			s.atEOF = true
+			return
+		}
+		if err != nil {
+			s.err = err
+			return
+		}
+		if n > 0 {
+			return
+		}
+	}
+
+	s.err = io.ErrNoProgress
+}
+
+// endReader is an io.RuneReader over a string that records whether it was read until the end, used to know whether
+// a regular expression needs more data to decide on a match.
+type endReader struct {
+	data   string
+	hitEnd bool
+}
+
+func (r *endReader) ReadRune() (rune, int, error) {
+	if r.data == "" || !utf8.FullRuneInString(r.data) {
+		r.hitEnd = true
+	}
+	if r.data == "" {
+		return 0, 0, io.EOF
+	}
+
+	c, size := utf8.DecodeRuneInString(r.data)
+	r.data = r.data[size:]
+	return c, size, nil
+}
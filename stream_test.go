@@ -0,0 +1,154 @@
+package sqlparse
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func scanAll(t *testing.T, s *Scanner) []Token {
+	var tokens []Token
+	for s.Scan() {
+		tokens = append(tokens, s.Token())
+	}
+	require.NoError(t, s.Err(), "Scanner.Err")
+
+	return tokens
+}
+
+func TestScannerMatchesGetTokens(t *testing.T) {
+	queries := append([]string{
+		"SELECT 'a long string spanning several reads' FROM foo -- and a long comment\r\nORDER  BY x",
+		"SELECT ção, 'ação' FROM foo",
+	}, scannerCorpus...)
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			expected, _ := GetTokensTolerant(query)
+
+			s := NewScanner(iotest.OneByteReader(strings.NewReader(query)))
+			s.Buffer(3, DefaultMaxTokenSize)
+			s.Tolerant(true)
+			assert.Equal(t, expected, scanAll(t, s))
+		})
+	}
+}
+
+func FuzzScannerMatchesGetTokens(f *testing.F) {
+	for _, query := range scannerCorpus {
+		f.Add(query, uint8(1))
+	}
+
+	f.Fuzz(func(t *testing.T, query string, readSize uint8) {
+		expected, expectedDiagnostics := GetTokensTolerant(query)
+
+		s := NewScanner(iotest.HalfReader(strings.NewReader(query)))
+		s.Buffer(int(readSize)+1, DefaultMaxTokenSize)
+		s.Tolerant(true)
+		require.Equal(t, expected, scanAll(t, s))
+		require.Equal(t, expectedDiagnostics, s.Diagnostics())
+	})
+}
+
+func TestScannerTolerant(t *testing.T) {
+	query := "SELECT a }} b,\n{c} FROM foo WHERE " + strings.Repeat("x + ", 20) + "{d}"
+	expected, expectedDiagnostics := GetTokensTolerant(query)
+
+	s := NewScanner(iotest.HalfReader(strings.NewReader(query)))
+	s.Buffer(4, DefaultMaxTokenSize)
+	s.Tolerant(true)
+	assert.Equal(t, expected, scanAll(t, s))
+
+	require.Len(t, s.Diagnostics(), len(expectedDiagnostics))
+	for i, diagnostic := range s.Diagnostics() {
+		assert.Equal(t, expectedDiagnostics[i].Position, diagnostic.Position)
+		assert.Equal(t, expectedDiagnostics[i].Msg, diagnostic.Msg)
+		assert.Equal(t, expectedDiagnostics[i].Snippet, diagnostic.Snippet)
+	}
+}
+
+func TestScannerSyntaxError(t *testing.T) {
+	s := NewScanner(strings.NewReader("SELECT *\nFROM foo WHERE a = {"))
+	for s.Scan() {
+	}
+
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, s.Err(), &syntaxErr)
+	assert.Equal(t, Position{Offset: 28, Line: 2, Column: 20}, syntaxErr.Position)
+	assert.Equal(t, "FROM foo WHERE a = {\n                   ^", syntaxErr.Snippet)
+}
+
+func TestScannerSyntaxErrorSnippet(t *testing.T) {
+	queries := []string{
+		"SELECT *\nFROM foo WHERE a = {",
+		"SELECT *\r\nFROM foo WHERE a = {",
+		"SELECT * FROM foo WHERE " + strings.Repeat("a + ", 20) + "{",
+		"SELECT 'ção', " + strings.Repeat("'é' || ", 10) + "{",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			_, expected := GetTokens(query)
+			var expectedErr *SyntaxError
+			require.ErrorAs(t, expected, &expectedErr)
+
+			s := NewScanner(iotest.OneByteReader(strings.NewReader(query)))
+			s.Buffer(3, DefaultMaxTokenSize)
+			for s.Scan() {
+			}
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, s.Err(), &syntaxErr)
+			assert.Equal(t, expectedErr.Snippet, syntaxErr.Snippet)
+		})
+	}
+}
+
+func TestScannerTokenTooLong(t *testing.T) {
+	s := NewScanner(strings.NewReader("SELECT '" + strings.Repeat("x", 100) + "'"))
+	s.Buffer(16, 64)
+	for s.Scan() {
+	}
+	assert.ErrorIs(t, s.Err(), ErrTokenTooLong)
+}
+
+func TestScannerReadError(t *testing.T) {
+	s := NewScanner(iotest.TimeoutReader(strings.NewReader("SELECT * FROM foo")))
+	s.Buffer(8, DefaultMaxTokenSize)
+	for s.Scan() {
+	}
+	assert.ErrorIs(t, s.Err(), iotest.ErrTimeout)
+}
+
+func TestScannerRegexpAcrossReads(t *testing.T) {
+	l := DefaultLexer().Clone().AddRegexp(regexp.MustCompile(`\{[^}]*\}`), TokenName).Build()
+
+	s := l.NewScanner(iotest.OneByteReader(strings.NewReader("SELECT {a b c}")))
+	s.Buffer(2, DefaultMaxTokenSize)
+	tokens := scanAll(t, s)
+
+	require.Len(t, tokens, 3)
+	assert.Equal(t, Token{
+		Value: "{a b c}",
+		Type:  TokenName,
+		Start: Position{Offset: 7, Line: 1, Column: 8},
+		End:   Position{Offset: 14, Line: 1, Column: 15},
+	}, tokens[2])
+}
+
+func BenchmarkScanner(b *testing.B) {
+	const query = "SELECT a.id, b.name, 'it''s' AS s, 3.1415 FROM a LEFT JOIN b ON a.id = b.a_id -- join\nWHERE a.x >= 10 ORDER BY a.id;\n"
+	data := strings.Repeat(query, 1000)
+
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		s := NewScanner(strings.NewReader(data))
+		for s.Scan() {
+		}
+		if err := s.Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+-- schema of a small shop, followed by the queries of its reports
+CREATE TABLE customers (
+    id BIGINT PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    email VARCHAR(320) UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE orders (
+    id BIGINT PRIMARY KEY,
+    customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total DECIMAL(12, 2) NOT NULL,
+    placed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX orders_customer_idx ON orders (customer_id, placed_at);
+
+INSERT INTO customers (id, name, email) VALUES (1, 'Ada', 'ada@example.com'), (2, 'Grace', NULL);
+
+/* monthly revenue per customer, keeping the customers without orders */
+WITH monthly AS (
+    SELECT o.customer_id, EXTRACT(YEAR FROM o.placed_at) AS year, EXTRACT(MONTH FROM o.placed_at) AS month,
+           SUM(o.total) AS revenue, COUNT(*) AS order_count
+    FROM orders o
+    WHERE o.status IN ('paid', 'shipped') AND o.placed_at >= DATE '2024-01-01'
+    GROUP BY o.customer_id, EXTRACT(YEAR FROM o.placed_at), EXTRACT(MONTH FROM o.placed_at)
+)
+SELECT c.id, c.name, COALESCE(m.revenue, 0) AS revenue, m.order_count,
+       RANK() OVER (PARTITION BY m.year, m.month ORDER BY m.revenue DESC) AS position,
+       CASE WHEN m.revenue > 1000.50 THEN 'gold' WHEN m.revenue > 100 THEN 'silver' ELSE 'bronze' END AS tier
+FROM customers c
+LEFT OUTER JOIN monthly m ON m.customer_id = c.id
+WHERE c.email IS NOT NULL AND c.name NOT LIKE 'test%'
+ORDER BY revenue DESC NULLS LAST, c.name
+LIMIT 50 OFFSET 0;
+
+UPDATE orders SET status = 'cancelled' WHERE status = 'pending' AND placed_at < CURRENT_DATE - INTERVAL '30' DAY;
+
+DELETE FROM customers WHERE NOT EXISTS (SELECT 1 FROM orders WHERE orders.customer_id = customers.id);
+
+SELECT status, COUNT(DISTINCT customer_id) AS customers, AVG(total)::numeric(10, 2) AS average
+FROM orders
+GROUP BY status
+HAVING COUNT(*) > 10
+UNION ALL
+SELECT 'all', COUNT(DISTINCT customer_id), AVG(total)::numeric(10, 2) FROM orders;
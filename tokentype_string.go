@@ -22,11 +22,16 @@ func _() {
 	_ = x[TokenNumberFloat-11]
 	_ = x[TokenString-12]
 	_ = x[TokenComment-13]
+	_ = x[TokenError-14]
+	_ = x[TokenCommentBlock-15]
+	_ = x[TokenCommentHint-16]
+	_ = x[TokenQuotedName-17]
+	_ = x[TokenParameter-18]
 }
 
-const _TokenType_name = "UnknownWhitespaceNewlineKeywordKeywordCTEOperatorUseAsKeywordPunctuationNameWildcardNumberIntegerNumberFloatStringComment"
+const _TokenType_name = "UnknownWhitespaceNewlineKeywordKeywordCTEOperatorUseAsKeywordPunctuationNameWildcardNumberIntegerNumberFloatStringCommentErrorCommentBlockCommentHintQuotedNameParameter"
 
-var _TokenType_index = [...]uint8{0, 7, 17, 24, 31, 41, 49, 61, 72, 76, 84, 97, 108, 114, 121}
+var _TokenType_index = [...]uint8{0, 7, 17, 24, 31, 41, 49, 61, 72, 76, 84, 97, 108, 114, 121, 126, 138, 149, 159, 168}
 
 func (i TokenType) String() string {
 	if i < 0 || i >= TokenType(len(_TokenType_index)-1) {